@@ -1,11 +1,14 @@
 package godiff
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/Grant-Nelson/goDiff/comparable"
+	"github.com/Grant-Nelson/goDiff/internal/hirschberg"
+	"github.com/Grant-Nelson/goDiff/internal/wagner"
 )
 
 const (
@@ -84,6 +87,27 @@ func runBenchmarks(b *testing.B, comp comparable.Comparable, suffix string) {
 				HybridDiff(-1, true, 500)(comp)
 			}
 		})
+
+	b.Run(fmt.Sprintf(`Myers%s`, suffix),
+		func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				MyersDiff(-1)(comp)
+			}
+		})
+
+	b.Run(fmt.Sprintf(`Patience%s`, suffix),
+		func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				PatienceDiff(nil)(comp)
+			}
+		})
+
+	b.Run(fmt.Sprintf(`Histogram%s`, suffix),
+		func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				HistogramDiff(-1, true, 500)(comp)
+			}
+		})
 }
 
 func Benchmark_Simple_Comparison(b *testing.B) {
@@ -131,3 +155,180 @@ func Benchmark_Variant_Comparison(b *testing.B) {
 		}
 	}
 }
+
+func Benchmark_Interned_LongLines(b *testing.B) {
+	const lineCount, lineLength = 300, 200
+	a, c := make([]string, lineCount), make([]string, lineCount)
+	prefix := strings.Repeat(`x`, lineLength)
+	for i := range a {
+		a[i] = prefix + fmt.Sprint(i%50)
+		c[i] = prefix + fmt.Sprint((i*7)%50)
+	}
+	comp := comparable.NewString(a, c)
+
+	b.Run(`Wagner-String`, func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			WagnerDiff(-1)(comp)
+		}
+	})
+
+	b.Run(`Wagner-Interned`, func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			InternDiff(WagnerDiff(-1))(comp)
+		}
+	})
+}
+
+func Benchmark_Discard_Unmatched(b *testing.B) {
+	const lineCount = 1000
+	a, c := make([]string, lineCount), make([]string, lineCount)
+	aValues, cValues := make([]interface{}, lineCount), make([]interface{}, lineCount)
+	for i := range a {
+		a[i], c[i] = fmt.Sprint(i%20), fmt.Sprint((i*7)%20)
+		if i%2 == 0 {
+			a[i], c[i] = fmt.Sprint(`a`, i), fmt.Sprint(`c`, i)
+		}
+		aValues[i], cValues[i] = a[i], c[i]
+	}
+
+	// The interface comparable isn't hashable so nothing can be discarded.
+	b.Run(`Hybrid-Kept`, func(b *testing.B) {
+		comp := comparable.NewInterface(aValues, cValues, nil)
+		for n := 0; n < b.N; n++ {
+			DefaultDiff()(comp)
+		}
+	})
+
+	b.Run(`Hybrid-Discarded`, func(b *testing.B) {
+		comp := comparable.NewString(a, c)
+		for n := 0; n < b.N; n++ {
+			DefaultDiff()(comp)
+		}
+	})
+}
+
+func Benchmark_Wagner_Costs(b *testing.B) {
+	comp := comparable.NewChar(billNyeA, billNyeB)
+
+	b.Run(`Uniform`, func(b *testing.B) {
+		diff := WagnerDiff(-1)
+		for n := 0; n < b.N; n++ {
+			diff(comp)
+		}
+	})
+
+	// A cost function, even one which returns the constant cost,
+	// makes the costs be looked up for every entry of the matrix.
+	b.Run(`Func`, func(b *testing.B) {
+		costs := DefaultCosts()
+		costs.RemoveFunc = func(aIndex int) int { return costs.Remove }
+		diff := wrap(wagner.New(-1), costs, true, true)
+		for n := 0; n < b.N; n++ {
+			diff(comp)
+		}
+	})
+}
+
+func Benchmark_Discard_Small(b *testing.B) {
+	comps := map[string]comparable.Comparable{
+		`Example`: comparable.NewString(exampleA, exampleB),
+		`Kitten`:  comparable.NewChar(`kitten`, `sitting`),
+		`Same`:    comparable.NewString(exampleA, exampleA),
+	}
+	for name, comp := range comps {
+		// Small comparables are below the cutoff so aren't checked for entries to discard,
+		// the diff should take the same time as running the diff without the check.
+		b.Run(name+`-Default`, func(b *testing.B) {
+			diff := DefaultDiff()
+			for n := 0; n < b.N; n++ {
+				diff(comp)
+			}
+		})
+
+		b.Run(name+`-NoCheck`, func(b *testing.B) {
+			diff := hirschberg.New(wagner.New(DefaultWagnerThreshold), -1, true)
+			for n := 0; n < b.N; n++ {
+				run(context.Background(), comp, diff, nil, true)
+			}
+		})
+	}
+}
+
+func Benchmark_Parallel_Hybrid(b *testing.B) {
+	inputA := strings.Repeat(billNyeA, 8)
+	inputB := strings.Repeat(billNyeB, 8)
+	comp := comparable.NewChar(inputA, inputB)
+
+	b.Run(`Hybrid`, func(b *testing.B) {
+		diff := HybridDiff(-1, true, DefaultWagnerThreshold)
+		for n := 0; n < b.N; n++ {
+			diff(comp)
+		}
+	})
+
+	for _, parallelism := range []int{2, 4, 8} {
+		b.Run(fmt.Sprint(`Parallel-`, parallelism), func(b *testing.B) {
+			diff := ParallelHybridDiff(-1, true, DefaultWagnerThreshold, parallelism)
+			for n := 0; n < b.N; n++ {
+				diff(comp)
+			}
+		})
+	}
+}
+
+// Benchmark_Concurrent_Split shows the time saved by calculating both halves of each split
+// at the same time. This needs at least two CPUs to be faster than the sequential diff.
+func Benchmark_Concurrent_Split(b *testing.B) {
+	inputA := strings.Repeat(billNyeA, 8)
+	inputB := strings.Repeat(billNyeB, 8)
+	comp := comparable.NewChar(inputA, inputB)
+
+	b.Run(`Hirschberg`, func(b *testing.B) {
+		diff := HirschbergDiff(-1, true)
+		for n := 0; n < b.N; n++ {
+			diff(comp)
+		}
+	})
+
+	b.Run(`Hirschberg-Concurrent`, func(b *testing.B) {
+		diff := ConcurrentHirschbergDiff(-1, true)
+		for n := 0; n < b.N; n++ {
+			diff(comp)
+		}
+	})
+
+	b.Run(`Hybrid`, func(b *testing.B) {
+		diff := HybridDiff(-1, true, DefaultWagnerThreshold)
+		for n := 0; n < b.N; n++ {
+			diff(comp)
+		}
+	})
+
+	b.Run(`Hybrid-Concurrent`, func(b *testing.B) {
+		diff := ConcurrentHybridDiff(-1, true, DefaultWagnerThreshold)
+		for n := 0; n < b.N; n++ {
+			diff(comp)
+		}
+	})
+}
+
+func Benchmark_Pooled_Concurrent(b *testing.B) {
+	comp := comparable.NewString(strings.Split(billNyeA, ` `), strings.Split(billNyeB, ` `))
+
+	b.Run(`Default-New`, func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				DefaultDiff()(comp)
+			}
+		})
+	})
+
+	b.Run(`Default-Pooled`, func(b *testing.B) {
+		diff := PooledDiff(nil)
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				diff(comp)
+			}
+		})
+	})
+}
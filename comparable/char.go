@@ -1,6 +1,6 @@
 package comparable
 
-var _ Comparable = (*Char)(nil)
+var _ Hashable = (*Char)(nil)
 
 // Char is a comparable for two strings.
 type Char struct {
@@ -35,6 +35,16 @@ func (comp *Char) Equals(aIndex, bIndex int) bool {
 	return comp.a[aIndex] == comp.b[bIndex]
 }
 
+// AHash gets the hash of the entry in the first list at the given index.
+func (comp *Char) AHash(aIndex int) uint64 {
+	return uint64(comp.a[aIndex])
+}
+
+// BHash gets the hash of the entry in the second list at the given index.
+func (comp *Char) BHash(bIndex int) uint64 {
+	return uint64(comp.b[bIndex])
+}
+
 // AValue gets the value from the A source at the given index.
 func (comp *Char) AValue(aIndex int) byte {
 	return comp.a[aIndex]
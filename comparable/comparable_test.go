@@ -2,6 +2,7 @@ package comparable
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -35,6 +36,43 @@ func Test_Integer(t *testing.T) {
 	strEqual(t, BPart(comp, 1), `4`, `BPart(Integer, 1)`)
 }
 
+func Test_Slice(t *testing.T) {
+	comp := NewSlice(
+		[]Cat{{name: `kitty`}, {name: `mittens`}, {name: `tom`}},
+		[]Cat{{name: `kitty`}, {name: `tom`}})
+	intEqual(t, comp.ALength(), 3, `Slice.ALength`)
+	intEqual(t, comp.BLength(), 2, `Slice.BLength`)
+	boolEqual(t, comp.Equals(0, 0), true, `Slice.Equals(0, 0)`)
+	boolEqual(t, comp.Equals(0, 1), false, `Slice.Equals(0, 1)`)
+	boolEqual(t, comp.Equals(1, 0), false, `Slice.Equals(1, 0)`)
+	boolEqual(t, comp.Equals(1, 1), false, `Slice.Equals(1, 1)`)
+	boolEqual(t, comp.Equals(2, 1), true, `Slice.Equals(2, 1)`)
+	strEqual(t, comp.AValue(1).name, `mittens`, `Slice.AValue(1)`)
+	strEqual(t, comp.BValue(1).name, `tom`, `Slice.BValue(1)`)
+}
+
+func Test_SliceFunc(t *testing.T) {
+	const epsilon = 0.001
+	comp := NewSliceFunc(
+		[]float64{1.2345, 1.23, 3.14159},
+		[]float64{1.235, 1.2356},
+		func(a, b float64) bool {
+			if a > b {
+				return a-b < epsilon
+			}
+			return b-a < epsilon
+		})
+	intEqual(t, comp.ALength(), 3, `SliceFunc.ALength`)
+	intEqual(t, comp.BLength(), 2, `SliceFunc.BLength`)
+	boolEqual(t, comp.Equals(0, 0), true, `SliceFunc.Equals(0, 0)`)
+	boolEqual(t, comp.Equals(0, 1), false, `SliceFunc.Equals(0, 1)`)
+	boolEqual(t, comp.Equals(1, 0), false, `SliceFunc.Equals(1, 0)`)
+	boolEqual(t, comp.Equals(1, 1), false, `SliceFunc.Equals(1, 1)`)
+	boolEqual(t, comp.Equals(2, 0), false, `SliceFunc.Equals(2, 0)`)
+	strEqual(t, fmt.Sprint(comp.AValue(1)), `1.23`, `SliceFunc.AValue(1)`)
+	strEqual(t, fmt.Sprint(comp.BValue(1)), `1.2356`, `SliceFunc.BValue(1)`)
+}
+
 func Test_Interface_Float(t *testing.T) {
 	const epsilon = 0.001
 	comp := NewInterface(
@@ -128,6 +166,105 @@ func Test_String(t *testing.T) {
 	strEqual(t, BPart(comp, 1), `d`, `BPart(String, 1)`)
 }
 
+func Test_Normalized(t *testing.T) {
+	comp := NewNormalized(NewString(
+		[]string{`a  b `, `c`, `d`},
+		[]string{`a b`, ` d`}), IgnoreSpaceChange)
+	intEqual(t, comp.ALength(), 3, `Normalized.ALength`)
+	intEqual(t, comp.BLength(), 2, `Normalized.BLength`)
+	boolEqual(t, comp.Equals(0, 0), true, `Normalized.Equals(0, 0)`)
+	boolEqual(t, comp.Equals(1, 0), false, `Normalized.Equals(1, 0)`)
+	boolEqual(t, comp.Equals(2, 1), false, `Normalized.Equals(2, 1)`)
+	strEqual(t, comp.AValue(0), `a  b `, `Normalized.AValue(0)`)
+	strEqual(t, comp.BValue(1), ` d`, `Normalized.BValue(1)`)
+	checkHashes(t, comp, `Normalized`)
+}
+
+func Test_Whitespace(t *testing.T) {
+	strEqual(t, IgnoreAllSpace(" a \tb\u00a0c \n"), `abc`, `IgnoreAllSpace`)
+	strEqual(t, IgnoreSpaceChange(" a \t b  c \n"), ` a b c`, `IgnoreSpaceChange`)
+	strEqual(t, IgnoreSpaceChange(``), ``, `IgnoreSpaceChange`)
+	strEqual(t, IgnoreTrailingSpace(" a  b \t\r"), ` a  b`, `IgnoreTrailingSpace`)
+}
+
+func Test_NormalizedRunes(t *testing.T) {
+	comp := NewNormalizedRunes(NewRunes([]rune("aBc"), []rune("bAd")), FoldCase)
+	intEqual(t, comp.ALength(), 3, `NormalizedRunes.ALength`)
+	intEqual(t, comp.BLength(), 3, `NormalizedRunes.BLength`)
+	boolEqual(t, comp.Equals(0, 1), true, `NormalizedRunes.Equals(0, 1)`)
+	boolEqual(t, comp.Equals(1, 0), true, `NormalizedRunes.Equals(1, 0)`)
+	boolEqual(t, comp.Equals(2, 2), false, `NormalizedRunes.Equals(2, 2)`)
+	intEqual(t, int(comp.AValue(1)), 'B', `NormalizedRunes.AValue(1)`)
+	checkHashes(t, comp, `NormalizedRunes`)
+
+	// The Angstrom sign is canonically equivalent to the letter A with a ring above.
+	comp = NewNormalizedRunes(NewRunes([]rune("\u212B"), []rune("\u00C5")), Canonical)
+	boolEqual(t, comp.Equals(0, 0), true, `NormalizedRunes.Equals(0, 0)`)
+	intEqual(t, int(comp.BValue(0)), 0xC5, `NormalizedRunes.BValue(0)`)
+}
+
+func Test_NormalizedRuneSlice(t *testing.T) {
+	comp := NewNormalizedRuneSlice(NewRuneSlice(
+		[][]rune{[]rune("caf\u00E9"), []rune(`Tea`)},
+		[][]rune{[]rune(`TEA`), []rune("CAFE\u0301")}), Chain(Canonical, FoldCase))
+	boolEqual(t, comp.Equals(0, 1), true, `NormalizedRuneSlice.Equals(0, 1)`)
+	boolEqual(t, comp.Equals(1, 0), true, `NormalizedRuneSlice.Equals(1, 0)`)
+	boolEqual(t, comp.Equals(0, 0), false, `NormalizedRuneSlice.Equals(0, 0)`)
+	strEqual(t, string(comp.AValue(0)), "caf\u00E9", `NormalizedRuneSlice.AValue(0)`)
+	strEqual(t, string(comp.BValue(0)), `TEA`, `NormalizedRuneSlice.BValue(0)`)
+	checkHashes(t, comp, `NormalizedRuneSlice`)
+}
+
+func Test_FoldCase(t *testing.T) {
+	values := []string{`hello`, `HELLO`, `HeLLo`, `hellø`, `HELLØ`, "\u212Aelvin", `kelvin`, `straße`, `STRASSE`, `ǅ`, `ǆ`, `Ǆ`, `σς`, `ΣΣ`, ``}
+	for _, a := range values {
+		for _, b := range values {
+			boolEqual(t, FoldCase(a) == FoldCase(b), strings.EqualFold(a, b), `FoldCase(`+a+`) == FoldCase(`+b+`)`)
+		}
+	}
+}
+
+func Test_Canonical(t *testing.T) {
+	strEqual(t, Canonical("caf\u00E9"), "cafe\u0301", `Canonical(precomposed)`)
+	strEqual(t, Canonical("cafe\u0301"), "cafe\u0301", `Canonical(decomposed)`)
+	strEqual(t, Canonical("\u1E69"), "s\u0323\u0307", `Canonical(recursive)`)
+	strEqual(t, Canonical("s\u0307\u0323"), "s\u0323\u0307", `Canonical(reordered)`)
+	strEqual(t, Canonical("\uD55C"), "\u1112\u1161\u11AB", `Canonical(Hangul)`)
+	strEqual(t, Canonical("\uAC00"), "\u1100\u1161", `Canonical(Hangul without trail)`)
+	strEqual(t, Canonical("\uFB01"), "\uFB01", `Canonical(compatibility only)`)
+	strEqual(t, Chain()(`Abc`), `Abc`, `Chain()`)
+	strEqual(t, Chain(Canonical, FoldCase, IgnoreAllSpace)("\u00C9 t\u00C9"), "E\u0301TE\u0301", `Chain`)
+}
+
+func Test_Interned(t *testing.T) {
+	str := NewString(
+		[]string{`a`, `b`, `a`, `c`, `d`, `d`},
+		[]string{`b`, `a`, `e`, `a`, `e`})
+	comp := NewInterned(str)
+	intEqual(t, comp.ALength(), 6, `Interned.ALength`)
+	intEqual(t, comp.BLength(), 5, `Interned.BLength`)
+	checkInterned(t, comp, str)
+	checkHashes(t, comp, `Interned`)
+
+	// Every entry has the same hash so the IDs must be verified with Equals.
+	colliding := &collidingHash{NewString(
+		[]string{`a`, `b`, `a`, `c`},
+		[]string{`c`, `b`, `b`, `d`})}
+	checkInterned(t, NewInterned(colliding), colliding)
+}
+
+func Test_Hashable(t *testing.T) {
+	checkHashes(t, NewChar(`abca`, `cab`), `Char`)
+	checkHashes(t, NewInteger([]int{1, 2, 3, 1}, []int{3, 1, 2}), `Integer`)
+	checkHashes(t, NewRunes([]rune(`abca`), []rune(`cab`)), `Runes`)
+	checkHashes(t, NewRuneSlice(
+		[][]rune{[]rune(`cat`), []rune(`dog`), []rune(`ca`), []rune(`cat`)},
+		[][]rune{[]rune(`ca`), []rune(`cat`), []rune(`dog`)}), `RuneSlice`)
+	checkHashes(t, NewString(
+		[]string{`cat`, `dog`, `ca`, `cat`},
+		[]string{`ca`, `cat`, `dog`}), `String`)
+}
+
 func Test_Custom_Float(t *testing.T) {
 	comp := &Float{
 		a:       []float64{1.2345, 1.23, 3.14159},
@@ -144,6 +281,39 @@ func Test_Custom_Float(t *testing.T) {
 	strEqual(t, BPart(comp, 1), `?`, `BPart(Float, 1)`)
 }
 
+// checkHashes checks that the given hashable has the same hashes for equal entries
+// and different hashes for unequal entries. The given hashable must have no collisions.
+func checkHashes(t *testing.T, comp Hashable, name string) {
+	for i := comp.ALength() - 1; i >= 0; i-- {
+		for j := comp.BLength() - 1; j >= 0; j-- {
+			boolEqual(t, comp.AHash(i) == comp.BHash(j), comp.Equals(i, j),
+				fmt.Sprintf(`%s.AHash(%d) == %s.BHash(%d)`, name, i, name, j))
+		}
+	}
+}
+
+// checkInterned checks that the interned comparable has the same equality as the original.
+func checkInterned(t *testing.T, comp *Interned, orig Comparable) {
+	for i := 0; i < orig.ALength(); i++ {
+		for j := 0; j < orig.BLength(); j++ {
+			boolEqual(t, comp.Equals(i, j), orig.Equals(i, j), fmt.Sprintf(`Interned.Equals(%d, %d)`, i, j))
+		}
+	}
+}
+
+// collidingHash is a hashable comparable where every entry has the same hash.
+type collidingHash struct {
+	*String
+}
+
+func (comp *collidingHash) AHash(aIndex int) uint64 {
+	return 7
+}
+
+func (comp *collidingHash) BHash(bIndex int) uint64 {
+	return 7
+}
+
 func boolEqual(t *testing.T, value, exp bool, msg string) {
 	if value != exp {
 		t.Error(fmt.Sprint("Unexpected boolean value:",
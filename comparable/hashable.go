@@ -0,0 +1,47 @@
+package comparable
+
+// Hashable is a comparable which can also provide a hash for each of its entries.
+// This is used by algorithms which need to group or count equal entries,
+// such as the patience diff, since those can not be found efficiently with
+// only the Equals method.
+//
+// Any two entries which are equal must have the same hash. Two entries with the
+// same hash are not required to be equal, so Equals is still used to verify.
+type Hashable interface {
+	Comparable
+
+	// AHash gets the hash of the entry in the first list at the given index.
+	AHash(aIndex int) uint64
+
+	// BHash gets the hash of the entry in the second list at the given index.
+	BHash(bIndex int) uint64
+}
+
+const (
+	// fnvOffset is the 64-bit FNV-1a offset basis.
+	fnvOffset uint64 = 14695981039346656037
+
+	// fnvPrime is the 64-bit FNV-1a prime.
+	fnvPrime uint64 = 1099511628211
+)
+
+// hashString gets the FNV-1a hash of the given string.
+func hashString(s string) uint64 {
+	hash := fnvOffset
+	for i := 0; i < len(s); i++ {
+		hash ^= uint64(s[i])
+		hash *= fnvPrime
+	}
+	return hash
+}
+
+// hashRunes gets the FNV-1a hash of the given runes.
+// Each rune is mixed in as a whole instead of byte by byte.
+func hashRunes(r []rune) uint64 {
+	hash := fnvOffset
+	for _, value := range r {
+		hash ^= uint64(value)
+		hash *= fnvPrime
+	}
+	return hash
+}
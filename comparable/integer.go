@@ -1,6 +1,6 @@
 package comparable
 
-var _ Comparable = (*Integer)(nil)
+var _ Hashable = (*Integer)(nil)
 
 // Integer is a comparable for two integer slices.
 type Integer struct {
@@ -31,6 +31,16 @@ func (comp *Integer) Equals(aIndex, bIndex int) bool {
 	return comp.a[aIndex] == comp.b[bIndex]
 }
 
+// AHash gets the hash of the entry in the first list at the given index.
+func (comp *Integer) AHash(aIndex int) uint64 {
+	return uint64(comp.a[aIndex])
+}
+
+// BHash gets the hash of the entry in the second list at the given index.
+func (comp *Integer) BHash(bIndex int) uint64 {
+	return uint64(comp.b[bIndex])
+}
+
 // AValue gets the value from the A source at the given index.
 func (comp *Integer) AValue(aIndex int) int {
 	return comp.a[aIndex]
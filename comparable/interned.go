@@ -0,0 +1,87 @@
+package comparable
+
+var _ Hashable = (*Interned)(nil)
+
+// Interned is a comparable where each entry of another hashable comparable has been
+// replaced with an integer ID, so that comparing entries is only an integer comparison.
+// This is useful when the entries are expensive to compare, such as long lines,
+// since the algorithms may compare each entry many times.
+//
+// An entry in A and an entry in B have the same ID only if they are equal.
+// The IDs are verified with Equals so entries with colliding hashes still get different IDs.
+type Interned struct {
+	Integer
+}
+
+// NewInterned constructs a new interned comparable for the given hashable comparable.
+// Each entry is hashed once and Equals is only called between entries with the same hash.
+// The original comparable should still be used to get the values of the entries.
+func NewInterned(comp Hashable) *Interned {
+	aLength, bLength := comp.ALength(), comp.BLength()
+	aHashes := make([]uint64, aLength)
+	aBuckets := map[uint64][]int{}
+	for i := range aHashes {
+		aHashes[i] = comp.AHash(i)
+		aBuckets[aHashes[i]] = append(aBuckets[aHashes[i]], i)
+	}
+	bBuckets := map[uint64][]int{}
+	for j := 0; j < bLength; j++ {
+		hash := comp.BHash(j)
+		bBuckets[hash] = append(bBuckets[hash], j)
+	}
+
+	interned := &Interned{
+		Integer: Integer{
+			a: make([]int, aLength),
+			b: make([]int, bLength),
+		},
+	}
+	for i := range interned.a {
+		interned.a[i] = -1
+	}
+	for j := range interned.b {
+		interned.b[j] = -1
+	}
+
+	// The IDs are given in the order of the entries so that the IDs are deterministic.
+	nextID := 0
+	for aRep, hash := range aHashes {
+		if interned.a[aRep] >= 0 {
+			continue
+		}
+
+		// Start a new ID with this A entry and find the B entries equal to it.
+		id := nextID
+		nextID++
+		interned.a[aRep] = id
+		bRep := -1
+		for _, j := range bBuckets[hash] {
+			if interned.b[j] < 0 && comp.Equals(aRep, j) {
+				interned.b[j] = id
+				if bRep < 0 {
+					bRep = j
+				}
+			}
+		}
+
+		// The other A entries equal to the found B entry are equal to this A entry.
+		// Entries without any match on the other side can't be equal to anything,
+		// so there is no need to find which of them are equal to each other.
+		if bRep >= 0 {
+			for _, i := range aBuckets[hash] {
+				if interned.a[i] < 0 && comp.Equals(i, bRep) {
+					interned.a[i] = id
+				}
+			}
+		}
+	}
+
+	// The B entries without any equal A entry get their own IDs.
+	for j, id := range interned.b {
+		if id < 0 {
+			interned.b[j] = nextID
+			nextID++
+		}
+	}
+	return interned
+}
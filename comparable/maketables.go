@@ -0,0 +1,127 @@
+//go:build ignore
+
+// maketables generates the Unicode tables used for the canonical decomposition
+// of strings from the Unicode character database file, UnicodeData.txt.
+//
+// Usage:
+//
+//	go run maketables.go -version 14.0.0 UnicodeData.txt > tables.go
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type combiningRange struct {
+	lo, hi rune
+	class  int
+}
+
+func main() {
+	version := flag.String(`version`, ``, `the version of the Unicode character database`)
+	flag.Parse()
+	if flag.NArg() != 1 || len(*version) <= 0 {
+		fmt.Fprintln(os.Stderr, `usage: go run maketables.go -version <version> <UnicodeData.txt>`)
+		os.Exit(2)
+	}
+
+	file, err := os.Open(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	type decomposition struct {
+		r     rune
+		parts []rune
+	}
+	decomps := []decomposition{}
+	ranges := []combiningRange{}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), `;`)
+		if len(fields) < 6 {
+			continue
+		}
+		r := parseRune(fields[0])
+
+		if class, _ := strconv.Atoi(fields[3]); class != 0 {
+			if last := len(ranges) - 1; last >= 0 && ranges[last].hi == r-1 && ranges[last].class == class {
+				ranges[last].hi = r
+			} else {
+				ranges = append(ranges, combiningRange{lo: r, hi: r, class: class})
+			}
+		}
+
+		// Only canonical decompositions are used, compatibility decompositions start with a tag.
+		if len(fields[5]) > 0 && !strings.HasPrefix(fields[5], `<`) {
+			parts := []rune{}
+			for _, part := range strings.Fields(fields[5]) {
+				parts = append(parts, parseRune(part))
+			}
+			decomps = append(decomps, decomposition{r: r, parts: parts})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	fmt.Fprintln(out, `// Code generated by "go run maketables.go"; DO NOT EDIT.`)
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, `package comparable`)
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, `// unicodeVersion is the version of the Unicode character database the tables were generated from.`)
+	fmt.Fprintf(out, "const unicodeVersion = %q\n", *version)
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, `// decompositions are the canonical decompositions of runes into one or two runes.`)
+	fmt.Fprintln(out, `// A decomposition into one rune has zero for the second rune.`)
+	fmt.Fprintln(out, `var decompositions = map[rune][2]rune{`)
+	for i, d := range decomps {
+		second := rune(0)
+		if len(d.parts) > 1 {
+			second = d.parts[1]
+		}
+		sep := ` `
+		if i%4 == 0 {
+			sep = "\t"
+		}
+		fmt.Fprintf(out, "%s0x%04X: {0x%04X, 0x%04X},", sep, d.r, d.parts[0], second)
+		if i%4 == 3 || i == len(decomps)-1 {
+			fmt.Fprintln(out)
+		}
+	}
+	fmt.Fprintln(out, `}`)
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, `// combiningClasses are the ranges of runes with a non-zero canonical combining class, in order.`)
+	fmt.Fprintln(out, `var combiningClasses = []combiningRange{`)
+	for i, c := range ranges {
+		sep := ` `
+		if i%4 == 0 {
+			sep = "\t"
+		}
+		fmt.Fprintf(out, "%s{0x%04X, 0x%04X, %d},", sep, c.lo, c.hi, c.class)
+		if i%4 == 3 || i == len(ranges)-1 {
+			fmt.Fprintln(out)
+		}
+	}
+	fmt.Fprintln(out, `}`)
+}
+
+func parseRune(text string) rune {
+	value, err := strconv.ParseUint(text, 16, 32)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	return rune(value)
+}
@@ -0,0 +1,199 @@
+package comparable
+
+import (
+	"strings"
+	"unicode"
+)
+
+var (
+	_ Hashable = (*Normalized)(nil)
+	_ Hashable = (*NormalizedRunes)(nil)
+	_ Hashable = (*NormalizedRuneSlice)(nil)
+)
+
+type (
+	// Normalizer changes a string into the form which is compared,
+	// such as removing the whitespace from the string.
+	Normalizer func(s string) string
+
+	// normalizedKeys is the normalized form of each entry in the two lists being compared.
+	normalizedKeys struct {
+		aKeys []string
+		bKeys []string
+	}
+
+	// Normalized is a comparable for two string slices which compares the strings after
+	// they have been normalized. The values are still the original strings
+	// so that the original strings can be output.
+	Normalized struct {
+		normalizedKeys
+		comp *String
+	}
+
+	// NormalizedRunes is a comparable for two runes which compares the runes after they
+	// have been normalized. Each rune is normalized by itself, so a rune can't be
+	// equal to a sequence of runes, such as a precomposed rune and its decomposition.
+	// The values are still the original runes so that the original runes can be output.
+	NormalizedRunes struct {
+		normalizedKeys
+		comp *Runes
+	}
+
+	// NormalizedRuneSlice is a comparable for two rune slices which compares the rune slices
+	// after they have been normalized. The values are still the original rune slices
+	// so that the original rune slices can be output.
+	NormalizedRuneSlice struct {
+		normalizedKeys
+		comp *RuneSlice
+	}
+)
+
+// NewNormalized constructs a new normalized string slice comparable.
+// Each string in the given comparable is normalized once with the given normalizer.
+func NewNormalized(comp *String, normalizer Normalizer) *Normalized {
+	return &Normalized{
+		normalizedKeys: newNormalizedKeys(len(comp.a), len(comp.b), func(index int, a bool) string {
+			if a {
+				return normalizer(comp.a[index])
+			}
+			return normalizer(comp.b[index])
+		}),
+		comp: comp,
+	}
+}
+
+// NewNormalizedRunes constructs a new normalized runes comparable.
+// Each rune in the given comparable is normalized once with the given normalizer.
+func NewNormalizedRunes(comp *Runes, normalizer Normalizer) *NormalizedRunes {
+	return &NormalizedRunes{
+		normalizedKeys: newNormalizedKeys(len(comp.a), len(comp.b), func(index int, a bool) string {
+			if a {
+				return normalizer(string(comp.a[index]))
+			}
+			return normalizer(string(comp.b[index]))
+		}),
+		comp: comp,
+	}
+}
+
+// NewNormalizedRuneSlice constructs a new normalized rune slice comparable.
+// Each rune slice in the given comparable is normalized once with the given normalizer.
+func NewNormalizedRuneSlice(comp *RuneSlice, normalizer Normalizer) *NormalizedRuneSlice {
+	return &NormalizedRuneSlice{
+		normalizedKeys: newNormalizedKeys(len(comp.a), len(comp.b), func(index int, a bool) string {
+			if a {
+				return normalizer(string(comp.a[index]))
+			}
+			return normalizer(string(comp.b[index]))
+		}),
+		comp: comp,
+	}
+}
+
+// newNormalizedKeys creates the normalized keys for the two lists with the given lengths.
+// The given normalize function gets the key for the entry at the given index in A or B.
+func newNormalizedKeys(aLength, bLength int, normalize func(index int, a bool) string) normalizedKeys {
+	keys := normalizedKeys{
+		aKeys: make([]string, aLength),
+		bKeys: make([]string, bLength),
+	}
+	for i := range keys.aKeys {
+		keys.aKeys[i] = normalize(i, true)
+	}
+	for i := range keys.bKeys {
+		keys.bKeys[i] = normalize(i, false)
+	}
+	return keys
+}
+
+// ALength is the length of the first list being compared.
+func (keys *normalizedKeys) ALength() int {
+	return len(keys.aKeys)
+}
+
+// BLength is the length of the second list being compared.
+func (keys *normalizedKeys) BLength() int {
+	return len(keys.bKeys)
+}
+
+// Equals determines if the normalized entries in the two given indices are equal.
+func (keys *normalizedKeys) Equals(aIndex, bIndex int) bool {
+	return keys.aKeys[aIndex] == keys.bKeys[bIndex]
+}
+
+// AHash gets the hash of the normalized entry in the first list at the given index.
+func (keys *normalizedKeys) AHash(aIndex int) uint64 {
+	return hashString(keys.aKeys[aIndex])
+}
+
+// BHash gets the hash of the normalized entry in the second list at the given index.
+func (keys *normalizedKeys) BHash(bIndex int) uint64 {
+	return hashString(keys.bKeys[bIndex])
+}
+
+// AValue gets the original value from the A source at the given index.
+func (comp *Normalized) AValue(aIndex int) string {
+	return comp.comp.AValue(aIndex)
+}
+
+// BValue gets the original value from the B source at the given index.
+func (comp *Normalized) BValue(bIndex int) string {
+	return comp.comp.BValue(bIndex)
+}
+
+// AValue gets the original value from the A source at the given index.
+func (comp *NormalizedRunes) AValue(aIndex int) rune {
+	return comp.comp.AValue(aIndex)
+}
+
+// BValue gets the original value from the B source at the given index.
+func (comp *NormalizedRunes) BValue(bIndex int) rune {
+	return comp.comp.BValue(bIndex)
+}
+
+// AValue gets the original value from the A source at the given index.
+func (comp *NormalizedRuneSlice) AValue(aIndex int) []rune {
+	return comp.comp.AValue(aIndex)
+}
+
+// BValue gets the original value from the B source at the given index.
+func (comp *NormalizedRuneSlice) BValue(bIndex int) []rune {
+	return comp.comp.BValue(bIndex)
+}
+
+// IgnoreAllSpace is a normalizer which removes all whitespace,
+// similar to the `-w` option of GNU diff.
+func IgnoreAllSpace(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// IgnoreSpaceChange is a normalizer which ignores changes in the amount of whitespace,
+// similar to the `-b` option of GNU diff. Each run of whitespace is replaced with
+// a single space and whitespace at the end of the string is removed.
+func IgnoreSpaceChange(s string) string {
+	buf := &strings.Builder{}
+	inSpace := false
+	for _, r := range IgnoreTrailingSpace(s) {
+		if unicode.IsSpace(r) {
+			inSpace = true
+			continue
+		}
+		if inSpace {
+			buf.WriteByte(' ')
+			inSpace = false
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
+
+// IgnoreTrailingSpace is a normalizer which removes whitespace at the end of the string,
+// similar to the `-Z` option of GNU diff.
+func IgnoreTrailingSpace(s string) string {
+	return strings.TrimRightFunc(s, unicode.IsSpace)
+}
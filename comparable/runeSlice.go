@@ -1,6 +1,6 @@
 package comparable
 
-var _ Comparable = (*RuneSlice)(nil)
+var _ Hashable = (*RuneSlice)(nil)
 
 // RuneSlice is a comparable for two string slices.
 type RuneSlice struct {
@@ -40,6 +40,16 @@ func (comp *RuneSlice) Equals(aIndex, bIndex int) bool {
 	return true
 }
 
+// AHash gets the hash of the entry in the first list at the given index.
+func (comp *RuneSlice) AHash(aIndex int) uint64 {
+	return hashRunes(comp.a[aIndex])
+}
+
+// BHash gets the hash of the entry in the second list at the given index.
+func (comp *RuneSlice) BHash(bIndex int) uint64 {
+	return hashRunes(comp.b[bIndex])
+}
+
 // AValue gets the value from the A source at the given index.
 func (comp *RuneSlice) AValue(aIndex int) []rune {
 	return comp.a[aIndex]
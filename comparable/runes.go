@@ -1,6 +1,6 @@
 package comparable
 
-var _ Comparable = (*Runes)(nil)
+var _ Hashable = (*Runes)(nil)
 
 // Runes is a comparable for two runes.
 type Runes struct {
@@ -31,6 +31,16 @@ func (comp *Runes) Equals(aIndex, bIndex int) bool {
 	return comp.a[aIndex] == comp.b[bIndex]
 }
 
+// AHash gets the hash of the entry in the first list at the given index.
+func (comp *Runes) AHash(aIndex int) uint64 {
+	return uint64(comp.a[aIndex])
+}
+
+// BHash gets the hash of the entry in the second list at the given index.
+func (comp *Runes) BHash(bIndex int) uint64 {
+	return uint64(comp.b[bIndex])
+}
+
 // AValue gets the value from the A source at the given index.
 func (comp *Runes) AValue(aIndex int) rune {
 	return comp.a[aIndex]
@@ -0,0 +1,91 @@
+package comparable
+
+var (
+	_ Comparable = (*Slice[int])(nil)
+	_ Comparable = (*SliceFunc[int])(nil)
+)
+
+type (
+	// Slice is a comparable for two slices of any comparable type.
+	// The entries are compared with the default equal (==) without boxing them.
+	Slice[T comparable] struct {
+		a []T
+		b []T
+	}
+
+	// SliceFunc is a comparable for two slices of any type
+	// where the entries are compared with an equal function.
+	SliceFunc[T any] struct {
+		a      []T
+		b      []T
+		equals func(a, b T) bool
+	}
+)
+
+// NewSlice constructs a new comparable for two slices of any comparable type.
+func NewSlice[T comparable](a, b []T) *Slice[T] {
+	return &Slice[T]{
+		a: a,
+		b: b,
+	}
+}
+
+// ALength is the length of the first list being compared.
+func (comp *Slice[T]) ALength() int {
+	return len(comp.a)
+}
+
+// BLength is the length of the second list being compared.
+func (comp *Slice[T]) BLength() int {
+	return len(comp.b)
+}
+
+// Equals determines if the entries in the two given indices are equal.
+func (comp *Slice[T]) Equals(aIndex, bIndex int) bool {
+	return comp.a[aIndex] == comp.b[bIndex]
+}
+
+// AValue gets the value from the A source at the given index.
+func (comp *Slice[T]) AValue(aIndex int) T {
+	return comp.a[aIndex]
+}
+
+// BValue gets the value from the B source at the given index.
+func (comp *Slice[T]) BValue(bIndex int) T {
+	return comp.b[bIndex]
+}
+
+// NewSliceFunc constructs a new comparable for two slices of any type
+// which are compared with the given equal function. The equal function must not be nil.
+func NewSliceFunc[T any](a, b []T, equals func(a, b T) bool) *SliceFunc[T] {
+	return &SliceFunc[T]{
+		a:      a,
+		b:      b,
+		equals: equals,
+	}
+}
+
+// ALength is the length of the first list being compared.
+func (comp *SliceFunc[T]) ALength() int {
+	return len(comp.a)
+}
+
+// BLength is the length of the second list being compared.
+func (comp *SliceFunc[T]) BLength() int {
+	return len(comp.b)
+}
+
+// Equals determines if the entries in the two given indices are equal.
+func (comp *SliceFunc[T]) Equals(aIndex, bIndex int) bool {
+	return comp.equals(comp.a[aIndex], comp.b[bIndex])
+}
+
+// AValue gets the value from the A source at the given index.
+func (comp *SliceFunc[T]) AValue(aIndex int) T {
+	return comp.a[aIndex]
+}
+
+// BValue gets the value from the B source at the given index.
+func (comp *SliceFunc[T]) BValue(bIndex int) T {
+	return comp.b[bIndex]
+}
@@ -1,6 +1,6 @@
 package comparable
 
-var _ Comparable = (*String)(nil)
+var _ Hashable = (*String)(nil)
 
 // String is a comparable for two string slices.
 type String struct {
@@ -31,6 +31,16 @@ func (comp *String) Equals(aIndex, bIndex int) bool {
 	return comp.a[aIndex] == comp.b[bIndex]
 }
 
+// AHash gets the hash of the entry in the first list at the given index.
+func (comp *String) AHash(aIndex int) uint64 {
+	return hashString(comp.a[aIndex])
+}
+
+// BHash gets the hash of the entry in the second list at the given index.
+func (comp *String) BHash(bIndex int) uint64 {
+	return hashString(comp.b[bIndex])
+}
+
 // AValue gets the value from the A source at the given index.
 func (comp *String) AValue(aIndex int) string {
 	return comp.a[aIndex]
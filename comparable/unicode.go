@@ -0,0 +1,119 @@
+package comparable
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+//go:generate sh -c "go run maketables.go -version 14.0.0 UnicodeData.txt > tables.go"
+
+const (
+	// hangulBase is the first precomposed Hangul syllable.
+	hangulBase = 0xAC00
+
+	// hangulCount is the number of precomposed Hangul syllables.
+	hangulCount = 11172
+
+	// hangulLeadBase, hangulVowelBase, and hangulTrailBase are the first of each Hangul jamo.
+	hangulLeadBase, hangulVowelBase, hangulTrailBase = 0x1100, 0x1161, 0x11A7
+
+	// hangulVowelCount and hangulTrailCount are the number of the Hangul vowel and trailing jamo.
+	hangulVowelCount, hangulTrailCount = 21, 28
+)
+
+// combiningRange is a range of runes, inclusive, with the same canonical combining class.
+type combiningRange struct {
+	lo, hi rune
+	class  int
+}
+
+// FoldCase is a normalizer which ignores the letter case, so that two strings are equal
+// when they would be equal with strings.EqualFold. Each rune is replaced with
+// the smallest rune which is equivalent to it under simple Unicode case folding.
+func FoldCase(s string) string {
+	return strings.Map(foldRune, s)
+}
+
+// foldRune gets the smallest rune which is equivalent to the given rune under simple case folding.
+func foldRune(r rune) rune {
+	min := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < min {
+			min = f
+		}
+	}
+	return min
+}
+
+// Canonical is a normalizer which ignores the differences between canonically equivalent strings,
+// such as a precomposed "é" and an "e" followed by a combining acute accent.
+// The string is put into the canonical decomposition form, NFD.
+func Canonical(s string) string {
+	runes := make([]rune, 0, len(s))
+	for _, r := range s {
+		runes = decompose(runes, r)
+	}
+
+	// Put each run of combining marks into the canonical order.
+	for start := 0; start < len(runes); start++ {
+		if combiningClass(runes[start]) == 0 {
+			continue
+		}
+		end := start + 1
+		for end < len(runes) && combiningClass(runes[end]) != 0 {
+			end++
+		}
+		marks := runes[start:end]
+		sort.SliceStable(marks, func(i, j int) bool {
+			return combiningClass(marks[i]) < combiningClass(marks[j])
+		})
+		start = end
+	}
+	return string(runes)
+}
+
+// decompose appends the full canonical decomposition of the given rune.
+func decompose(runes []rune, r rune) []rune {
+	if index := r - hangulBase; index >= 0 && index < hangulCount {
+		runes = append(runes,
+			hangulLeadBase+index/(hangulVowelCount*hangulTrailCount),
+			hangulVowelBase+(index%(hangulVowelCount*hangulTrailCount))/hangulTrailCount)
+		if trail := index % hangulTrailCount; trail != 0 {
+			runes = append(runes, hangulTrailBase+trail)
+		}
+		return runes
+	}
+
+	parts, ok := decompositions[r]
+	if !ok {
+		return append(runes, r)
+	}
+	runes = decompose(runes, parts[0])
+	if parts[1] != 0 {
+		runes = decompose(runes, parts[1])
+	}
+	return runes
+}
+
+// combiningClass gets the canonical combining class of the given rune.
+func combiningClass(r rune) int {
+	index := sort.Search(len(combiningClasses), func(i int) bool {
+		return combiningClasses[i].hi >= r
+	})
+	if index < len(combiningClasses) && combiningClasses[index].lo <= r {
+		return combiningClasses[index].class
+	}
+	return 0
+}
+
+// Chain creates a normalizer which applies each of the given normalizers in order,
+// such as Chain(Canonical, FoldCase) to ignore both normalization and letter case.
+func Chain(normalizers ...Normalizer) Normalizer {
+	return func(s string) string {
+		for _, normalizer := range normalizers {
+			s = normalizer(s)
+		}
+		return s
+	}
+}
@@ -0,0 +1,63 @@
+package godiff
+
+import (
+	"context"
+
+	"github.com/Grant-Nelson/goDiff/comparable"
+)
+
+// AlgorithmContext is an instance of a diff algorithm configuration which can be
+// canceled with a context. If the context is canceled, or its deadline is exceeded,
+// before the diff has finished then the parts of the diff which were not finished
+// are given as removed and added. These best-effort results are returned with the
+// context's error. See Algorithm.WithContext for creating an AlgorithmContext.
+type AlgorithmContext func(ctx context.Context, comp comparable.Comparable) (Results, error)
+
+// contextComparable is a comparable with the context to cancel the diff with.
+// This is used to pass the context through an Algorithm into the algorithm's wrapper.
+type contextComparable struct {
+	comparable.Comparable
+	ctx context.Context
+}
+
+// withContext attaches the given context to the given comparable.
+func withContext(ctx context.Context, comp comparable.Comparable) comparable.Comparable {
+	return &contextComparable{
+		Comparable: comp,
+		ctx:        ctx,
+	}
+}
+
+// splitContext gets the context attached to the given comparable and the comparable
+// without the context. If there isn't an attached context the background context is used.
+func splitContext(comp comparable.Comparable) (context.Context, comparable.Comparable) {
+	if cc, ok := comp.(*contextComparable); ok {
+		return cc.ctx, cc.Comparable
+	}
+	return context.Background(), comp
+}
+
+// WithContext creates a version of this algorithm which can be canceled with a context.
+// The context is checked between each step the algorithm takes to divide the problem space
+// and between each row of the Wagner-Fischer matrix. If the diff failed, the error from
+// Results.Err is returned.
+func (alg Algorithm) WithContext() AlgorithmContext {
+	return func(ctx context.Context, comp comparable.Comparable) (Results, error) {
+		results := alg(withContext(ctx, comp))
+		if err := results.Err(); err != nil {
+			return results, err
+		}
+		if err := ctx.Err(); err != nil && results.Approximate() {
+			return results, err
+		}
+		return results, nil
+	}
+}
+
+// DiffContext will perform a diff on the given comparable information which can be
+// canceled with the given context. This will use a new instance of the default diff configuration.
+// If the context is canceled before the diff has finished, best-effort results are
+// returned with the context's error.
+func DiffContext(ctx context.Context, comp comparable.Comparable) (Results, error) {
+	return DefaultDiff().WithContext()(ctx, comp)
+}
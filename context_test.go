@@ -0,0 +1,135 @@
+package godiff
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Grant-Nelson/goDiff/comparable"
+	"github.com/Grant-Nelson/goDiff/internal/collector"
+	"github.com/Grant-Nelson/goDiff/step"
+)
+
+func Test_DiffContext_Background(t *testing.T) {
+	comp := comparable.NewString(exampleA, exampleB)
+	path, err := DiffContext(context.Background(), comp)
+	if err != nil {
+		t.Error("Unexpected error from DiffContext: ", err)
+	}
+	checkContextPath(t, path, Diff(comp).(*collector.Collector).String(), false)
+}
+
+func Test_DiffContext_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, diff := range []Algorithm{
+		DefaultDiff(),
+		HirschbergDiff(-1, false),
+		WagnerDiff(-1),
+		MyersDiff(-1),
+		PatienceDiff(nil),
+		AffineDiff(2, -1, true, DefaultWagnerThreshold),
+	} {
+		path, err := diff.WithContext()(ctx, comparable.NewChar(`kitten`, `sitting`))
+		if err != context.Canceled {
+			t.Error("Unexpected error from a canceled diff: ", err)
+		}
+		checkContextPath(t, path, `-6 +7`, true)
+
+		// The padding is still found before the diff is run.
+		path, err = diff.WithContext()(ctx, comparable.NewChar(`my kitten`, `my sitting`))
+		if err != context.Canceled {
+			t.Error("Unexpected error from a canceled diff: ", err)
+		}
+		checkContextPath(t, path, `=3 -6 +7`, true)
+
+		// The diff is finished without needing to check the context.
+		path, err = diff.WithContext()(ctx, comparable.NewChar(`kitten`, `kitten`))
+		if err != nil {
+			t.Error("Unexpected error from a finished diff: ", err)
+		}
+		checkContextPath(t, path, `=6`, false)
+	}
+
+	// Histogram doesn't remove the padding before the diff is run.
+	path, err := HistogramDiff(-1, true, DefaultWagnerThreshold).WithContext()(ctx, comparable.NewChar(`my kitten`, `my sitting`))
+	if err != context.Canceled {
+		t.Error("Unexpected error from a canceled diff: ", err)
+	}
+	checkContextPath(t, path, `-9 +10`, true)
+}
+
+// cancelingComparable is a comparable which cancels the context after a number of comparisons.
+type cancelingComparable struct {
+	comparable.Comparable
+	cancel func()
+	limit  int
+}
+
+func (comp *cancelingComparable) Equals(aIndex, bIndex int) bool {
+	comp.limit--
+	if comp.limit <= 0 {
+		comp.cancel()
+	}
+	return comp.Comparable.Equals(aIndex, bIndex)
+}
+
+func Test_DiffContext_Partial(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := strings.Repeat(strings.Join(exampleA, "\n"), 20)
+	b := strings.Repeat(strings.Join(exampleB, "\n"), 20)
+	comp := &cancelingComparable{
+		Comparable: comparable.NewChar(a, b),
+		cancel:     cancel,
+		limit:      100000,
+	}
+	path, err := HybridDiff(-1, true, 100).WithContext()(ctx, comp)
+	if err != context.Canceled {
+		t.Error("Unexpected error from a canceled diff: ", err)
+	}
+
+	aTotal, bTotal, equal := 0, 0, 0
+	path.Read(func(stepType step.Type, count int) {
+		switch stepType {
+		case step.Equal:
+			aTotal += count
+			bTotal += count
+			equal += count
+		case step.Removed:
+			aTotal += count
+		case step.Added:
+			bTotal += count
+		}
+	})
+	if aTotal != len(a) || bTotal != len(b) || equal <= 0 {
+		t.Error("Unexpected partial diff:",
+			"\n   A Total:  ", aTotal, " => ", len(a),
+			"\n   B Total:  ", bTotal, " => ", len(b),
+			"\n   Equal:    ", equal)
+	}
+}
+
+func Test_DiffContext_Deadline(t *testing.T) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	path, err := DiffContext(ctx, comparable.NewChar(`kitten`, `sitting`))
+	if err != context.DeadlineExceeded {
+		t.Error("Unexpected error from an expired diff: ", err)
+	}
+	checkContextPath(t, path, `-6 +7`, true)
+}
+
+// checkContextPath checks the path from a diff which could have been canceled.
+func checkContextPath(t *testing.T, path Results, exp string, expApprox bool) {
+	col := path.(*collector.Collector)
+	if result := col.String(); exp != result || col.Approximate() != expApprox {
+		t.Error("Context diff returned unexpected result:",
+			"\n   Expected: ", exp, " (approximate: ", expApprox, ")",
+			"\n   Result:   ", result, " (approximate: ", col.Approximate(), ")")
+	}
+}
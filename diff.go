@@ -1,10 +1,16 @@
 package godiff
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/Grant-Nelson/goDiff/comparable"
 	"github.com/Grant-Nelson/goDiff/internal/collector"
 	"github.com/Grant-Nelson/goDiff/internal/container"
 	"github.com/Grant-Nelson/goDiff/internal/hirschberg"
+	"github.com/Grant-Nelson/goDiff/internal/histogram"
+	"github.com/Grant-Nelson/goDiff/internal/myers"
+	"github.com/Grant-Nelson/goDiff/internal/patience"
 	"github.com/Grant-Nelson/goDiff/internal/wagner"
 	"github.com/Grant-Nelson/goDiff/step"
 )
@@ -22,11 +28,27 @@ type (
 
 		// Read will read the steps to take for this diff.
 		Read(hndl step.PathCallback)
+
+		// Approximate indicates if some parts of the diff were not fully run,
+		// because of an exceeded budget or a canceled context, and were given
+		// as removed and added. This means the path may not be minimal.
+		Approximate() bool
+
+		// Err is the error which occurred while performing or reading the diff, or nil if none.
+		// If the diff failed, such as because the comparable panicked, the results will be empty.
+		// Reading the results before the diff has finished will also set this error.
+		Err() error
 	}
 
+	// Costs is the configuration for how much each kind of edit costs.
+	// The diff algorithms which use these costs find the path with the lowest total cost.
+	// See CostDiff for using custom costs.
+	Costs = container.Costs
+
 	// Algorithm is an instance of a diff algorithm configuration which can be used
 	// multiple times for different input. This can help reduce memory pressure by
-	// reusing already allocated buffers.
+	// reusing already allocated buffers. An instance must not be used from multiple
+	// goroutines at the same time, see PooledDiff for an algorithm which can be.
 	Algorithm func(comp comparable.Comparable) Results
 )
 
@@ -40,18 +62,104 @@ const DefaultWagnerThreshold = 500
 var _ Results = (*collector.Collector)(nil)
 
 // wrap wraps an instance of a Diff into an Algorithm.
-func wrap(diff container.Diff) Algorithm {
-	return func(comp comparable.Comparable) Results {
-		col := collector.New()
-		cont := container.New(comp)
-		cont, before, after := cont.Reduce()
-		col.InsertEqual(after)
-		if !cont.EndCase(col) {
-			diff.Diff(cont, col)
+// The given costs are used by the diff, if nil the default costs are used.
+// If the comparable has an attached context the diff can be canceled with it.
+// The useReduce flag indicates if the equal padding edges should be
+// removed before the diff is run. The useDiscard flag indicates if, when the
+// costs allow it, the entries which have no equal entry on the other side are
+// discarded before the diff is run and are added back into the results as removed
+// and added. Discarding must not be used for the algorithms which anchor on how
+// often the parts occur, since joining the runs around the discarded entries
+// changes those counts.
+func wrap(diff container.Diff, costs *Costs, useReduce, useDiscard bool) Algorithm {
+	return func(comp comparable.Comparable) (results Results) {
+		defer func() {
+			if r := recover(); r != nil {
+				results = failed(r)
+			}
+		}()
+
+		ctx, comp := splitContext(comp)
+		if useDiscard && discardable(costs) {
+			if filter := discardUnmatched(ctx, comp); filter != nil {
+				return expandDiscarded(run(ctx, filter, diff, costs, useReduce), filter)
+			}
 		}
-		col.InsertEqual(before)
-		col.Finish()
-		return col
+		return run(ctx, comp, diff, costs, useReduce)
+	}
+}
+
+// run performs the given diff algorithm on the given comparable and returns the finished collector.
+func run(ctx context.Context, comp comparable.Comparable, diff container.Diff, costs *Costs, useReduce bool) *collector.Collector {
+	col := collector.New()
+	cont := container.NewContext(ctx, comp, costs)
+	before, after := 0, 0
+	if useReduce {
+		cont, before, after = cont.Reduce()
+	}
+	col.InsertEqual(after)
+	if !cont.EndCase(col) {
+		diff.Diff(cont, col)
+	}
+	col.InsertEqual(before)
+	col.Finish()
+	return col
+}
+
+// innerFailure is the panic value used to pass a failure from an
+// algorithm used inside of another algorithm out to the outer algorithm.
+type innerFailure struct {
+	err error
+}
+
+// failed creates empty results with an error for the given recovered panic value.
+// This keeps a malformed comparable from crashing the caller of the diff.
+func failed(r interface{}) Results {
+	col := collector.New()
+	col.Finish()
+	switch r := r.(type) {
+	case innerFailure:
+		col.SetErr(r.err)
+	case error:
+		col.SetErr(fmt.Errorf(`diff failed: %w`, r))
+	default:
+		col.SetErr(fmt.Errorf(`diff failed: %v`, r))
+	}
+	return col
+}
+
+// algorithmDiff is an adapter for using an Algorithm as a Diff inside of another algorithm.
+type algorithmDiff Algorithm
+
+// NoResizeNeeded determines if the diff algorithm can handle a container with
+// the amount of data inside of the given container.
+// The algorithm manages its own memory so this always returns true.
+func (diff algorithmDiff) NoResizeNeeded(cont *container.Container) bool {
+	return true
+}
+
+// Diff performs the algorithm on the given container
+// and writes the results to the collector.
+func (diff algorithmDiff) Diff(cont *container.Container, col *collector.Collector) {
+	path := diff(withContext(cont.Context(), cont))
+	if err := path.Err(); err != nil {
+		panic(innerFailure{err: err})
+	}
+	types := make([]step.Type, 0, path.Count())
+	counts := make([]int, 0, path.Count())
+	path.Read(func(stepType step.Type, count int) {
+		types = append(types, stepType)
+		counts = append(counts, count)
+	})
+
+	// The collector expects the steps in reverse order.
+	for i := len(types) - 1; i >= 0; i-- {
+		col.InsertStep(types[i], counts[i])
+	}
+
+	// Carry over that the algorithm's results were not fully run.
+	if path.Approximate() {
+		col.InsertCoarse(0, 0)
 	}
 }
 
@@ -62,7 +170,7 @@ func wrap(diff container.Diff) Algorithm {
 // The useReduce flag indicates if the equal padding edges should be checked
 // at each step of the algorithm or not.
 func HirschbergDiff(length int, useReduce bool) Algorithm {
-	return wrap(hirschberg.New(nil, length, useReduce))
+	return wrap(hirschberg.New(nil, length, useReduce), nil, true, true)
 }
 
 // WagnerDiff creates a new Wagner-Fischer algorithm instance for performing a diff.
@@ -70,7 +178,7 @@ func HirschbergDiff(length int, useReduce bool) Algorithm {
 // The given size is the amount of matrix space, width * height, to preallocate
 // for the Wagner-Fischer algorithm. Use -1 to not preallocate any matrix.
 func WagnerDiff(size int) Algorithm {
-	return wrap(wagner.New(size))
+	return wrap(wagner.New(size), nil, true, true)
 }
 
 // HybridDiff creates a new hybrid Hirschberg with Wagner-Fischer cutoff for performing a diff.
@@ -84,7 +192,171 @@ func WagnerDiff(size int) Algorithm {
 // This must be greater than 4 fo use the cutoff. The larger the size, the more memory is used
 // creating the matrix but the earlier the Wagner-Fischer algorithm can take over.
 func HybridDiff(length int, useReduce bool, size int) Algorithm {
-	return wrap(hirschberg.New(wagner.New(size), length, useReduce))
+	return wrap(hirschberg.New(wagner.New(size), length, useReduce), nil, true, true)
+}
+
+// ConcurrentHirschbergDiff creates a new Hirschberg algorithm instance for performing a diff
+// where the scores for the two halves of each large split are calculated at the same time
+// in two goroutines. This uses twice the score vector memory of HirschbergDiff.
+//
+// The results are the same as HirschbergDiff. The comparable must be safe to read from multiple goroutines,
+// which the comparables in the comparable package are as long as any given equality test is.
+// The given length and useReduce are the same as for HirschbergDiff.
+func ConcurrentHirschbergDiff(length int, useReduce bool) Algorithm {
+	return wrap(hirschberg.NewConcurrentSplit(nil, length, useReduce), nil, true, true)
+}
+
+// ConcurrentHybridDiff creates a new hybrid Hirschberg with Wagner-Fischer cutoff for performing
+// a diff where the scores for the two halves of each large split are calculated at the same time
+// in two goroutines. This uses twice the score vector memory of HybridDiff.
+//
+// The results are the same as HybridDiff. The comparable must be safe to read from multiple goroutines,
+// which the comparables in the comparable package are as long as any given equality test is.
+// The given length, useReduce, and size are the same as for HybridDiff.
+func ConcurrentHybridDiff(length int, useReduce bool, size int) Algorithm {
+	return wrap(hirschberg.NewConcurrentSplit(wagner.New(size), length, useReduce), nil, true, true)
+}
+
+// ParallelHirschbergDiff creates a new Hirschberg algorithm instance for performing a diff
+// where the independent parts left after each split are diffed at the same time.
+// While there are fewer parts than goroutines, the scores for the two halves of
+// each split are also calculated at the same time.
+//
+// The given parallelism is the maximum number of goroutines to diff with, each with its own
+// score vectors. Use 0 or less to use runtime.GOMAXPROCS goroutines. The results are the same
+// as HirschbergDiff for any parallelism. The comparable must be safe to read from multiple goroutines,
+// which the comparables in the comparable package are as long as any given equality test is.
+// The given length and useReduce are the same as for HirschbergDiff.
+func ParallelHirschbergDiff(length int, useReduce bool, parallelism int) Algorithm {
+	return wrap(hirschberg.NewParallel(nil, length, useReduce, parallelism), nil, true, true)
+}
+
+// ParallelHybridDiff creates a new hybrid Hirschberg with Wagner-Fischer cutoff for performing
+// a diff where the independent parts left after each split are diffed at the same time.
+// While there are fewer parts than goroutines, the scores for the two halves of
+// each split are also calculated at the same time.
+//
+// The given parallelism is the maximum number of goroutines to diff with, each with its own score
+// vectors and Wagner-Fischer matrix. Use 0 or less to use runtime.GOMAXPROCS goroutines. The results
+// are the same as HybridDiff for any parallelism. The comparable must be safe to read from multiple
+// goroutines, which the comparables in the comparable package are as long as any given equality test is.
+// The given length, useReduce, and size are the same as for HybridDiff.
+func ParallelHybridDiff(length int, useReduce bool, size int, parallelism int) Algorithm {
+	newHybrid := func() container.Diff { return wagner.New(size) }
+	return wrap(hirschberg.NewParallel(newHybrid, length, useReduce, parallelism), nil, true, true)
+}
+
+// HybridBudgetDiff creates a new hybrid Hirschberg with Wagner-Fischer cutoff for performing
+// a diff which stops looking for the minimal path once the given operation budget is exceeded.
+// This is useful when the inputs may be almost completely different, where finding the minimal
+// path is wasted effort.
+//
+// The budget is the maximum number of Hirschberg score and Wagner-Fischer matrix entries to
+// calculate, roughly proportional to the time the diff takes. Splitting, or using the Wagner-Fischer
+// cutoff on, a part of the comparable costs the product of the lengths of that part. Once the budget
+// would be exceeded the remaining parts are given as removed and added and Results.Approximate
+// will return true. Use -1 to not limit the operations.
+//
+// For large hashable comparables the entries which have no equal entry on the other side are
+// discarded before the diff, so the budget is only used on the remaining entries. This means
+// a large comparable may be diffed exactly with a budget which it would have exceeded otherwise.
+//
+// The given length, useReduce, and size are the same as for HybridDiff.
+func HybridBudgetDiff(length int, useReduce bool, size int, budget int) Algorithm {
+	return wrap(hirschberg.NewBudget(wagner.New(size), length, useReduce, budget), nil, true, true)
+}
+
+// MyersDiff creates a new Myers algorithm instance for performing a diff.
+// This runs in O((N+M)D) time, where D is the size of the diff, so it is
+// much faster than the other algorithms when the inputs are nearly the same.
+//
+// The given length is the initial size of the furthest reaching vectors. If the
+// vectors are too small they will be reallocated to the larger size.
+// Use -1 to not preallocate the vectors.
+func MyersDiff(length int) Algorithm {
+	return wrap(myers.New(length), nil, true, true)
+}
+
+// PatienceDiff creates a new patience algorithm instance for performing a diff.
+// This anchors on the parts which occur only once in both inputs, such as unique lines
+// of source code, so that the diff aligns those instead of braces and blank lines.
+//
+// The given fallback is used between the anchors when there are no more unique parts.
+// If the fallback is nil then the default diff configuration will be used.
+// The comparable must implement comparable.Hashable to find anchors,
+// otherwise the fallback is used for the whole comparable.
+func PatienceDiff(fallback Algorithm) Algorithm {
+	if fallback == nil {
+		fallback = DefaultDiff()
+	}
+	return wrap(patience.New(algorithmDiff(fallback)), nil, true, false)
+}
+
+// HistogramDiff creates a new histogram algorithm instance for performing a diff.
+// This anchors on the common parts which occur the least often in the inputs,
+// choosing the same anchors as `git diff --histogram`. Like git, neither the equal
+// padding edges nor the parts which have no equal part on the other side are removed
+// before the diff is run, since removing them can change how often the parts occur.
+// git also slides ambiguous changes up or down afterwards, which is not done here,
+// so those changes may be placed differently.
+//
+// The comparable must implement comparable.Hashable to count the occurrences,
+// otherwise the fallback is used for the whole comparable. The fallback is a hybrid
+// Hirschberg with Wagner-Fischer cutoff used where all the common parts occur too often.
+// The given length, useReduce, and size configure that fallback, see HybridDiff.
+func HistogramDiff(length int, useReduce bool, size int) Algorithm {
+	return wrap(histogram.New(hirschberg.New(wagner.New(size), length, useReduce)), nil, false, false)
+}
+
+// DefaultCosts creates a new set of costs with the default values.
+// These are the costs used for the Levenshtein distance.
+func DefaultCosts() *Costs {
+	return container.DefaultCosts()
+}
+
+// CostDiff creates a new hybrid Hirschberg with Wagner-Fischer cutoff for performing a diff
+// with the given costs. This can be used to make some edits cheaper or more expensive than others,
+// for example to make removing comment lines cheap. If the costs are nil the default costs are used.
+// The given length, useReduce, and size are the same as for HybridDiff, except the useReduce flag
+// also indicates if the equal padding edges are removed before the diff is run. When any of the
+// cost functions are set, reducing may not find the lowest total cost since the equal padding
+// edges could be cheaper to remove and add.
+func CostDiff(costs *Costs, length int, useReduce bool, size int) Algorithm {
+	return wrap(hirschberg.New(wagner.New(size), length, useReduce), costs, useReduce, true)
+}
+
+// affineCosts creates the default costs with the given gap open cost.
+// A substitution is output as a remove and an add, so it costs the same
+// as starting both runs to let substitutions be grouped with other edits.
+func affineCosts(gapOpen int) *Costs {
+	costs := DefaultCosts()
+	costs.GapOpen = gapOpen
+	costs.Substition = costs.Remove + costs.Add + gapOpen*2
+	return costs
+}
+
+// AffineWagnerDiff creates a new Wagner-Fischer algorithm instance with affine costs
+// for performing a diff. The given gap open cost is added whenever a run of removes or
+// adds is started so the edits are grouped into fewer, larger blocks.
+// The affine costs are found with Gotoh's algorithm which uses three matrices.
+//
+// The given size is the amount of matrix space, 3 * (width + 1) * (height + 1), to preallocate
+// for the algorithm. Use -1 to not preallocate any matrix.
+func AffineWagnerDiff(gapOpen int, size int) Algorithm {
+	return wrap(wagner.New(size), affineCosts(gapOpen), true, true)
+}
+
+// AffineDiff creates a new hybrid Hirschberg with Wagner-Fischer cutoff with affine costs
+// for performing a diff. The given gap open cost is added whenever a run of removes or
+// adds is started so the edits are grouped into fewer, larger blocks.
+//
+// The Hirschberg splits account for a run of removes crossing the split but the parts on
+// either side are diffed separately, so the result may cost slightly more than optimal.
+// The Wagner-Fischer cutoff finds the optimal affine path for the parts it is used on.
+// The given length, useReduce, and size are the same as for HybridDiff except the size
+// is for the affine matrices, see AffineWagnerDiff.
+func AffineDiff(gapOpen int, length int, useReduce bool, size int) Algorithm {
+	return wrap(hirschberg.New(wagner.New(size), length, useReduce), affineCosts(gapOpen), true, true)
 }
 
 // DefaultDiff creates the default diff algorithm with default configuration.
@@ -94,6 +366,13 @@ func DefaultDiff() Algorithm {
 	return HybridDiff(-1, true, DefaultWagnerThreshold)
 }
 
+// DefaultBudgetDiff creates the default diff algorithm with the given operation budget.
+// See HybridBudgetDiff for how the budget is used, including that for large hashable
+// comparables it is only used on the entries which have an equal entry on the other side.
+func DefaultBudgetDiff(budget int) Algorithm {
+	return HybridBudgetDiff(-1, true, DefaultWagnerThreshold, budget)
+}
+
 // Diff will perform a diff on the given comparable information.
 // This will use a new instance of the default diff configuration.
 func Diff(comp comparable.Comparable) Results {
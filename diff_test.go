@@ -1,6 +1,11 @@
 package godiff
 
 import (
+	"fmt"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -64,6 +69,61 @@ var (
 		`This paragraph contains`,
 		`important new additions`,
 		`to this document.`)
+
+	frobnitzA = lines(
+		`#include <stdio.h>`,
+		``,
+		`// Frobs foo heartily`,
+		`int frobnitz(int foo)`,
+		`{`,
+		`    int i;`,
+		`    for(i = 0; i < 10; i++)`,
+		`    {`,
+		`        printf("Your answer is: ");`,
+		`        printf("%d\n", foo);`,
+		`    }`,
+		`}`,
+		``,
+		`int fact(int n)`,
+		`{`,
+		`    if(n > 1)`,
+		`    {`,
+		`        return fact(n-1) * n;`,
+		`    }`,
+		`    return 1;`,
+		`}`,
+		``,
+		`int main(int argc, char **argv)`,
+		`{`,
+		`    frobnitz(fact(10));`,
+		`}`)
+
+	frobnitzB = lines(
+		`#include <stdio.h>`,
+		``,
+		`int fib(int n)`,
+		`{`,
+		`    if(n > 2)`,
+		`    {`,
+		`        return fib(n-1) + fib(n-2);`,
+		`    }`,
+		`    return 1;`,
+		`}`,
+		``,
+		`// Frobs foo heartily`,
+		`int frobnitz(int foo)`,
+		`{`,
+		`    int i;`,
+		`    for(i = 0; i < 10; i++)`,
+		`    {`,
+		`        printf("%d\n", foo);`,
+		`    }`,
+		`}`,
+		``,
+		`int main(int argc, char **argv)`,
+		`{`,
+		`    frobnitz(fib(10));`,
+		`}`)
 )
 
 func Test_Diff_Basics(t *testing.T) {
@@ -79,6 +139,228 @@ func Test_Diff_Basics(t *testing.T) {
 	checkLP(t, "ABC", "ADB", "=1 +1 =1 -1")
 }
 
+func Test_Diff_Myers(t *testing.T) {
+	diff := MyersDiff(-1)
+	checkAlg(t, diff, "A", "A", "=1")
+	checkAlg(t, diff, "A", "B", "-1 +1")
+	checkAlg(t, diff, "kitten", "sitting", "-1 +1 =3 -1 +1 =1 +1")
+	checkAlg(t, diff, "saturday", "sunday", "=1 -2 =1 -1 +1 =3")
+	checkAlg(t, diff, "ABC", "ADB", "=1 +1 =1 -1")
+	checkSlices(t, PlusMinusCustom(diff, exampleA, exampleB), hirschbergPlusMinus)
+}
+
+func Test_Diff_Parallel(t *testing.T) {
+	diff := ParallelHybridDiff(-1, true, DefaultWagnerThreshold, 4)
+	checkAlg(t, diff, "A", "A", "=1")
+	checkAlg(t, diff, "A", "B", "-1 +1")
+	checkAlg(t, diff, "kitten", "sitting", "-1 +1 =3 -1 +1 =1 +1")
+	checkAlg(t, diff, "saturday", "sunday", "=1 -2 =1 -1 +1 =3")
+	checkAlg(t, diff, "ABC", "ADB", "=1 +1 =1 -1")
+	checkSlices(t, PlusMinusCustom(diff, exampleA, exampleB), hirschbergPlusMinus)
+	checkSlices(t, PlusMinusCustom(ParallelHirschbergDiff(-1, true, 4), exampleA, exampleB), hirschbergPlusMinus)
+
+	// The inputs must be large enough to be split in parallel.
+	comp := comparable.NewChar(billNyeA+billNyeB, billNyeB+billNyeA)
+	exp := HybridDiff(-1, true, DefaultWagnerThreshold)(comp).(*collector.Collector).String()
+	for _, parallelism := range []int{0, 2, 8} {
+		result := ParallelHybridDiff(-1, true, DefaultWagnerThreshold, parallelism)(comp).(*collector.Collector).String()
+		strEqual(t, result, exp, fmt.Sprint(`parallelism `, parallelism))
+	}
+}
+
+func Test_Diff_Concurrent(t *testing.T) {
+	diff := ConcurrentHybridDiff(-1, true, DefaultWagnerThreshold)
+	checkAlg(t, diff, "A", "A", "=1")
+	checkAlg(t, diff, "A", "B", "-1 +1")
+	checkAlg(t, diff, "kitten", "sitting", "-1 +1 =3 -1 +1 =1 +1")
+	checkAlg(t, diff, "saturday", "sunday", "=1 -2 =1 -1 +1 =3")
+	checkAlg(t, diff, "ABC", "ADB", "=1 +1 =1 -1")
+	checkSlices(t, PlusMinusCustom(diff, exampleA, exampleB), hirschbergPlusMinus)
+	checkSlices(t, PlusMinusCustom(ConcurrentHirschbergDiff(-1, true), exampleA, exampleB), hirschbergPlusMinus)
+
+	// The inputs must be large enough for the halves of a split to be calculated at the same time.
+	comp := comparable.NewChar(billNyeA+billNyeB, billNyeB+billNyeA)
+	exp := HybridDiff(-1, true, DefaultWagnerThreshold)(comp).(*collector.Collector).String()
+	strEqual(t, diff(comp).(*collector.Collector).String(), exp, `concurrent hybrid`)
+	exp = HirschbergDiff(-1, false)(comp).(*collector.Collector).String()
+	strEqual(t, ConcurrentHirschbergDiff(-1, false)(comp).(*collector.Collector).String(), exp, `concurrent Hirschberg`)
+}
+
+func Test_Diff_Patience(t *testing.T) {
+	diff := PatienceDiff(nil)
+	checkAlg(t, diff, "A", "A", "=1")
+	checkAlg(t, diff, "A", "B", "-1 +1")
+	checkAlg(t, diff, "kitten", "sitting", "-1 +1 =3 -1 +1 =1 +1")
+	checkAlg(t, diff, "ABC", "ADB", "=1 +1 =1 -1")
+	checkSlices(t, PlusMinusCustom(diff, frobnitzA, frobnitzB), lines(
+		` #include <stdio.h>`,
+		` `,
+		`+int fib(int n)`,
+		`+{`,
+		`+    if(n > 2)`,
+		`+    {`,
+		`+        return fib(n-1) + fib(n-2);`,
+		`+    }`,
+		`+    return 1;`,
+		`+}`,
+		`+`,
+		` // Frobs foo heartily`,
+		` int frobnitz(int foo)`,
+		` {`,
+		`     int i;`,
+		`     for(i = 0; i < 10; i++)`,
+		`     {`,
+		`-        printf("Your answer is: ");`,
+		`         printf("%d\n", foo);`,
+		`     }`,
+		` }`,
+		` `,
+		`-int fact(int n)`,
+		`-{`,
+		`-    if(n > 1)`,
+		`-    {`,
+		`-        return fact(n-1) * n;`,
+		`-    }`,
+		`-    return 1;`,
+		`-}`,
+		`-`,
+		` int main(int argc, char **argv)`,
+		` {`,
+		`-    frobnitz(fact(10));`,
+		`+    frobnitz(fib(10));`,
+		` }`))
+}
+
+func Test_Diff_Histogram(t *testing.T) {
+	diff := HistogramDiff(-1, true, DefaultWagnerThreshold)
+	checkAlg(t, diff, "A", "A", "=1")
+	checkAlg(t, diff, "A", "B", "-1 +1")
+	checkAlg(t, diff, "kitten", "sitting", "-1 +1 =3 -1 +1 =1 +1")
+	checkAlg(t, diff, "ABC", "ADB", "=1 +1 =1 -1")
+
+	// Same as the output from `git diff --histogram`.
+	checkSlices(t, PlusMinusCustom(diff, exampleA, exampleB), hirschbergPlusMinus)
+}
+
+func Test_Diff_HistogramGit(t *testing.T) {
+	if _, err := exec.LookPath(`git`); err != nil {
+		t.Skip(`git is not available`)
+	}
+	checkGitHistogram(t, exampleA, exampleB)
+
+	// These inputs are large enough that discarding the unmatched lines, which git doesn't
+	// do for the histogram diff, would have changed the anchors which are picked.
+	for _, seed := range []int64{3, 4, 7} {
+		r := rand.New(rand.NewSource(seed))
+		randLines := func(unique string) []string {
+			result := make([]string, 150)
+			for i := range result {
+				if r.Intn(4) == 0 {
+					result[i] = fmt.Sprint(unique, i)
+				} else {
+					result[i] = fmt.Sprint(`line `, r.Intn(30))
+				}
+			}
+			return result
+		}
+		a, b := randLines(`a`), randLines(`b`)
+		checkGitHistogram(t, a, b)
+	}
+}
+
+// checkGitHistogram checks that the histogram diff of the given lines
+// is the same as the output from `git diff --histogram`.
+func checkGitHistogram(t *testing.T, a, b []string) {
+	dir := t.TempDir()
+	aFile, bFile := filepath.Join(dir, `a.txt`), filepath.Join(dir, `b.txt`)
+	if err := os.WriteFile(aFile, []byte(strings.Join(a, "\n")+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(bFile, []byte(strings.Join(b, "\n")+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The diff exits with 1 when there are differences, so only the output is checked.
+	context := fmt.Sprint(`-U`, len(a)+len(b))
+	output, _ := exec.Command(`git`, `diff`, `--no-index`, `--no-color`, `--histogram`, context, aFile, bFile).Output()
+	gitLines := strings.Split(strings.TrimSuffix(string(output), "\n"), "\n")
+	for i, line := range gitLines {
+		if strings.HasPrefix(line, `@@`) {
+			gitLines = gitLines[i+1:]
+			break
+		}
+	}
+	checkSlices(t, PlusMinusCustom(HistogramDiff(-1, true, DefaultWagnerThreshold), a, b), gitLines)
+}
+
+func Test_Diff_Costs(t *testing.T) {
+	checkAlg(t, CostDiff(nil, -1, true, 0), "ABC", "ADB", "=1 +1 =1 -1")
+
+	costs := DefaultCosts()
+	costs.RemoveFunc = func(aIndex int) int {
+		if aIndex == 2 {
+			return 10
+		}
+		return 1
+	}
+	checkAlg(t, CostDiff(costs, -1, true, 0), "ABC", "ADB", "=1 -2 +2")
+	checkAlg(t, CostDiff(costs, -1, true, DefaultWagnerThreshold), "ABC", "ADB", "=1 -2 +2")
+
+	costs = DefaultCosts()
+	costs.Add, costs.Remove, costs.Substition = 5, 5, 10
+	costs.RemoveFunc = func(aIndex int) int {
+		if aIndex%2 == 1 {
+			return 0
+		}
+		return 5
+	}
+	checkAlg(t, CostDiff(costs, -1, true, DefaultWagnerThreshold), "BA", "AB", "+1 =1 -1")
+	checkAlg(t, CostDiff(costs, -1, false, 0), "xAxBxCx", "ABC", "-1 =1 -1 =1 -1 =1 -1")
+}
+
+func Test_Diff_Affine(t *testing.T) {
+	for _, diff := range []Algorithm{
+		AffineWagnerDiff(3, -1),
+		AffineDiff(3, -1, true, 0),
+		AffineDiff(3, -1, false, DefaultWagnerThreshold),
+	} {
+		checkAlg(t, diff, "A", "A", "=1")
+		checkAlg(t, diff, "A", "B", "-1 +1")
+		checkAlg(t, diff, "kitten", "sitting", "-6 +7")
+		checkAlg(t, diff, "saturday", "sunday", "=1 -4 +2 =3")
+		checkAlg(t, diff, "ABCDEFG", "AxCxExG", "=1 -5 +5 =1")
+	}
+
+	exp := lines(
+		` one`,
+		`-two`,
+		`-three`,
+		`-four`,
+		`-five`,
+		`+2`,
+		`+three`,
+		`+4`,
+		`+5`,
+		` six`)
+	checkSlices(t, PlusMinusCustom(AffineDiff(2, -1, true, DefaultWagnerThreshold),
+		lines(`one`, `two`, `three`, `four`, `five`, `six`),
+		lines(`one`, `2`, `three`, `4`, `5`, `six`)), exp)
+}
+
+func Test_Diff_Budget(t *testing.T) {
+	checkApprox(t, DefaultBudgetDiff(-1), "kitten", "sitting", "-1 +1 =3 -1 +1 =1 +1", false)
+	checkApprox(t, DefaultBudgetDiff(1000), "kitten", "sitting", "-1 +1 =3 -1 +1 =1 +1", false)
+	checkApprox(t, DefaultBudgetDiff(0), "kitten", "sitting", "-6 +7", true)
+	checkApprox(t, DefaultBudgetDiff(0), "my kitten", "my sitting", "=3 -6 +7", true)
+	checkApprox(t, DefaultBudgetDiff(0), "kitten", "kitten", "=6", false)
+	checkApprox(t, HybridBudgetDiff(-1, false, 0, 1000), "kitten kitten", "sitting sitting",
+		"-1 +1 =3 -1 +1 =1 +1 =1 -1 +1 =3 -1 +1 =1 +1", false)
+	checkApprox(t, HybridBudgetDiff(-1, false, 0, 300), "kitten kitten", "sitting sitting",
+		"-6 +7 =1 -2 +2 =2 -1 +1 =1 +1", true)
+	checkApprox(t, PatienceDiff(DefaultBudgetDiff(0)), "kitten kitten", "sitting sitting",
+		"-5 +5 =1 +1 =1 -5 +5 =1 +1", true)
+}
+
 func Test_Diff_Words(t *testing.T) {
 	wordsA := strings.Split(billNyeA, ` `)
 	wordsB := strings.Split(billNyeB, ` `)
@@ -139,6 +421,32 @@ func checkLP(t *testing.T, a, b, exp string) {
 	}
 }
 
+// checkAlg checks the given algorithm's path for the given inputs.
+func checkAlg(t *testing.T, diff Algorithm, a, b, exp string) {
+	path := diff(comparable.NewChar(a, b))
+	result := path.(*collector.Collector).String()
+	if exp != result {
+		t.Error("Algorithm returned unexpected result:",
+			"\n   Input A:  ", a,
+			"\n   Input B:  ", b,
+			"\n   Expected: ", exp,
+			"\n   Result:   ", result)
+	}
+}
+
+// checkApprox checks the given algorithm's path and if it is approximate for the given inputs.
+func checkApprox(t *testing.T, diff Algorithm, a, b, exp string, expApprox bool) {
+	path := diff(comparable.NewChar(a, b))
+	result := path.(*collector.Collector).String()
+	if exp != result || path.Approximate() != expApprox {
+		t.Error("Algorithm returned unexpected result:",
+			"\n   Input A:  ", a,
+			"\n   Input B:  ", b,
+			"\n   Expected: ", exp, " (approximate: ", expApprox, ")",
+			"\n   Result:   ", result, " (approximate: ", path.Approximate(), ")")
+	}
+}
+
 // checkDiff gets the labelled differences for PlusMinus
 func checkDiff(t *testing.T, sep, a, b, exp string) {
 	aParts := strings.Split(a, sep)
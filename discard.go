@@ -0,0 +1,231 @@
+package godiff
+
+import (
+	"context"
+
+	"github.com/Grant-Nelson/goDiff/comparable"
+	"github.com/Grant-Nelson/goDiff/internal/collector"
+	"github.com/Grant-Nelson/goDiff/step"
+)
+
+// discardCutoff is the smallest A length times B length of a comparable which is checked
+// for entries to discard. Smaller comparables are quick enough to diff as is.
+const discardCutoff = 1 << 14
+
+// These flags indicate which sides of the comparable a hash was found in.
+const (
+	inA uint8 = 1 << iota
+	inB
+)
+
+type (
+	// filtered is a comparable for only some of the entries of another comparable.
+	filtered struct {
+		comp     comparable.Comparable
+		aIndices []int
+		bIndices []int
+	}
+
+	// hashableFiltered is a filtered comparable for a hashable comparable.
+	hashableFiltered struct {
+		filtered
+		hash comparable.Hashable
+	}
+
+	// pathRuns is a path which is built in order then put into a collector.
+	pathRuns struct {
+		types  []step.Type
+		counts []int
+	}
+)
+
+// newFiltered creates a comparable for only the entries at the given indices of the given comparable.
+// The filtered comparable is hashable if the given comparable is hashable.
+func newFiltered(comp comparable.Comparable, aIndices, bIndices []int) comparable.Comparable {
+	filter := filtered{
+		comp:     comp,
+		aIndices: aIndices,
+		bIndices: bIndices,
+	}
+	if hash, ok := comp.(comparable.Hashable); ok {
+		return &hashableFiltered{
+			filtered: filter,
+			hash:     hash,
+		}
+	}
+	return &filter
+}
+
+// ALength is the length of the first list being compared.
+func (comp *filtered) ALength() int {
+	return len(comp.aIndices)
+}
+
+// BLength is the length of the second list being compared.
+func (comp *filtered) BLength() int {
+	return len(comp.bIndices)
+}
+
+// Equals determines if the entries in the two given indices are equal.
+func (comp *filtered) Equals(aIndex, bIndex int) bool {
+	return comp.comp.Equals(comp.aIndices[aIndex], comp.bIndices[bIndex])
+}
+
+// AHash gets the hash of the entry in the first list at the given index.
+func (comp *hashableFiltered) AHash(aIndex int) uint64 {
+	return comp.hash.AHash(comp.aIndices[aIndex])
+}
+
+// BHash gets the hash of the entry in the second list at the given index.
+func (comp *hashableFiltered) BHash(bIndex int) uint64 {
+	return comp.hash.BHash(comp.bIndices[bIndex])
+}
+
+// add adds the given step to the end of the path.
+// If the last step has the same type, the new step is joined into it.
+func (p *pathRuns) add(stepType step.Type, count int) {
+	if count <= 0 {
+		return
+	}
+	if last := len(p.types) - 1; last >= 0 && p.types[last] == stepType {
+		p.counts[last] += count
+		return
+	}
+	p.types = append(p.types, stepType)
+	p.counts = append(p.counts, count)
+}
+
+// collect puts the path into a new finished collector.
+// If approximate is true the collector is marked as approximate.
+func (p *pathRuns) collect(approximate bool) *collector.Collector {
+	col := collector.New()
+
+	// The collector expects the steps in reverse order.
+	for i := len(p.types) - 1; i >= 0; i-- {
+		col.InsertStep(p.types[i], p.counts[i])
+	}
+	if approximate {
+		col.InsertCoarse(0, 0)
+	}
+	col.Finish()
+	return col
+}
+
+// discardable determines if the entries which have no equal entry on the other side
+// can be discarded before the diff without changing the cost of the lowest cost path.
+// This is true when each of those entries always costs the same to remove or add and
+// replacing one costs at least as much as removing and adding it, so pairing them up is never cheaper.
+func discardable(costs *Costs) bool {
+	if costs == nil {
+		return true
+	}
+	return costs.RemoveFunc == nil && costs.AddFunc == nil && costs.SubstitionFunc == nil &&
+		costs.GapOpen <= 0 && costs.Substition >= costs.Remove+costs.Add
+}
+
+// discardUnmatched gets the comparable without the entries which have no equal entry on
+// the other side, similar to the preprocessing done by GNU diff. This makes the space which the
+// diff algorithms have to search smaller. The entries are found with the hashes, since entries
+// with different hashes can't be equal, so this returns nil if the comparable isn't hashable
+// or if no entries can be discarded. Comparables smaller than the discardCutoff are not checked
+// since the check would cost more than it saves, nor are comparables whose context is canceled.
+func discardUnmatched(ctx context.Context, comp comparable.Comparable) *hashableFiltered {
+	hash, ok := comp.(comparable.Hashable)
+	if !ok || ctx.Err() != nil {
+		return nil
+	}
+	aLength, bLength := hash.ALength(), hash.BLength()
+	if aLength*bLength < discardCutoff {
+		return nil
+	}
+
+	sides := make(map[uint64]uint8, aLength)
+	for i := 0; i < aLength; i++ {
+		sides[hash.AHash(i)] = inA
+	}
+	var aIndices, bIndices []int
+	for j := 0; j < bLength; j++ {
+		h := hash.BHash(j)
+		side, found := sides[h]
+		if found && side&inB == 0 {
+			sides[h] = side | inB
+		}
+		bIndices = keepIndex(bIndices, j, bLength, found)
+	}
+	for i := 0; i < aLength; i++ {
+		aIndices = keepIndex(aIndices, i, aLength, sides[hash.AHash(i)]&inB != 0)
+	}
+
+	if aIndices == nil && bIndices == nil {
+		return nil
+	}
+	return newFiltered(hash, allIndices(aIndices, aLength), allIndices(bIndices, bLength)).(*hashableFiltered)
+}
+
+// keepIndex adds the given index to the given kept indices if keep is true.
+// The kept indices are nil until the first index which isn't kept, since before that
+// every index is kept, so nothing is allocated when no indices are discarded.
+func keepIndex(indices []int, index, length int, keep bool) []int {
+	if indices != nil {
+		if keep {
+			indices = append(indices, index)
+		}
+		return indices
+	}
+	if keep {
+		return nil
+	}
+	indices = make([]int, index, length)
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
+
+// allIndices gets the given kept indices or, if they are nil, all the indices for the given length.
+func allIndices(indices []int, length int) []int {
+	if indices != nil {
+		return indices
+	}
+	indices = make([]int, length)
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
+
+// expandDiscarded creates the results for the full comparable from the results of the diff
+// of the filtered comparable, adding the discarded entries back as removed or added
+// where they are between the entries which the diff of the filtered comparable kept.
+func expandDiscarded(path Results, filter *hashableFiltered) Results {
+	runs := &pathRuns{}
+	aPrev, bPrev := 0, 0
+	aIndex, bIndex := 0, 0
+	path.Read(func(stepType step.Type, count int) {
+		for i := 0; i < count; i++ {
+			switch stepType {
+			case step.Equal:
+				aNext, bNext := filter.aIndices[aIndex], filter.bIndices[bIndex]
+				runs.add(step.Removed, aNext-aPrev)
+				runs.add(step.Added, bNext-bPrev)
+				runs.add(step.Equal, 1)
+				aPrev, bPrev = aNext+1, bNext+1
+				aIndex++
+				bIndex++
+			case step.Added:
+				bNext := filter.bIndices[bIndex]
+				runs.add(step.Added, bNext-bPrev+1)
+				bPrev = bNext + 1
+				bIndex++
+			case step.Removed:
+				aNext := filter.aIndices[aIndex]
+				runs.add(step.Removed, aNext-aPrev+1)
+				aPrev = aNext + 1
+				aIndex++
+			}
+		}
+	})
+	runs.add(step.Removed, filter.comp.ALength()-aPrev)
+	runs.add(step.Added, filter.comp.BLength()-bPrev)
+	return runs.collect(path.Approximate())
+}
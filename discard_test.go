@@ -0,0 +1,149 @@
+package godiff
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/Grant-Nelson/goDiff/comparable"
+	"github.com/Grant-Nelson/goDiff/step"
+)
+
+func Test_Discard_Unmatched(t *testing.T) {
+	a, b, exp := []string{}, []string{}, []string{}
+	for i := 0; i < 40; i++ {
+		a = append(a, fmt.Sprint(`a`, i), fmt.Sprint(`x`, i), fmt.Sprint(`b`, i), fmt.Sprint(`c`, i), fmt.Sprint(`y`, i))
+		b = append(b, fmt.Sprint(`z`, i), fmt.Sprint(`a`, i), fmt.Sprint(`b`, i), fmt.Sprint(`w`, i), fmt.Sprint(`c`, i))
+		exp = append(exp, `+1 =1 -1 =1 +1 =1 -1`)
+	}
+	for _, diff := range []Algorithm{HirschbergDiff(-1, true), WagnerDiff(-1), MyersDiff(-1), PatienceDiff(nil), HistogramDiff(-1, true, -1)} {
+		checkPathString(t, diff(comparable.NewString(a, b)), strings.Join(exp, ` `))
+	}
+
+	// Nothing is left to diff when none of the lines match.
+	checkPathString(t, DefaultDiff()(comparable.NewString(a, lines(exp...))), `-200 +40`)
+}
+
+func Test_Discard_NotUsed(t *testing.T) {
+	// Costs where replacing is cheaper than removing and adding
+	// must see the unmatched parts to pair them up.
+	costs := DefaultCosts()
+	costs.Substition = 1
+	checkPathString(t, CostDiff(costs, -1, true, -1)(comparable.NewString(lines(`a`, `b`), lines(`c`, `b`))), `-1 +1 =1`)
+	boolEqual(t, discardable(costs), false, `discardable with cheap substitutions`)
+	boolEqual(t, discardable(affineCosts(1)), false, `discardable with affine costs`)
+	boolEqual(t, discardable(DefaultCosts()), true, `discardable with default costs`)
+	boolEqual(t, discardable(nil), true, `discardable with nil costs`)
+
+	a, b := make([]int, 200), make([]int, 200)
+	aValues, bValues := make([]interface{}, 200), make([]interface{}, 200)
+	for i := range a {
+		a[i], b[i] = i, 199-i
+		aValues[i], bValues[i] = a[i], b[i]
+	}
+	ctx := context.Background()
+
+	// Comparables which aren't hashable are diffed as is.
+	if discardUnmatched(ctx, comparable.NewInterface(aValues, bValues, nil)) != nil {
+		t.Error("Expected a comparable which isn't hashable to not be filtered.")
+	}
+
+	// Nothing is filtered when every entry has a match.
+	if discardUnmatched(ctx, comparable.NewInteger(a, b)) != nil {
+		t.Error("Expected a comparable where every entry matches to not be filtered.")
+	}
+
+	// Small comparables are not checked.
+	if discardUnmatched(ctx, comparable.NewString(lines(`a`, `b`), lines(`c`, `b`))) != nil {
+		t.Error("Expected a small comparable to not be filtered.")
+	}
+
+	// Comparables with a canceled context are not checked.
+	a[0] = -1
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if discardUnmatched(canceled, comparable.NewInteger(a, b)) != nil {
+		t.Error("Expected a comparable with a canceled context to not be filtered.")
+	}
+	if discardUnmatched(ctx, comparable.NewInteger(a, b)) == nil {
+		t.Error("Expected a comparable with an unmatched entry to be filtered.")
+	}
+}
+
+func Test_Discard_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	randLines := func(unique string) []string {
+		result := make([]string, 130+r.Intn(70))
+		for i := range result {
+			if r.Intn(3) == 0 {
+				result[i] = fmt.Sprint(unique, i)
+			} else {
+				result[i] = string(rune('a' + r.Intn(40)))
+			}
+		}
+		return result
+	}
+	toInterface := func(values []string) []interface{} {
+		result := make([]interface{}, len(values))
+		for i, value := range values {
+			result[i] = value
+		}
+		return result
+	}
+
+	for i := 0; i < 200; i++ {
+		a, b := randLines(`A`), randLines(`B`)
+
+		// The interface comparable isn't hashable so nothing is discarded.
+		exp := changeCount(WagnerDiff(-1)(comparable.NewInterface(toInterface(a), toInterface(b), nil)))
+		for _, diff := range []Algorithm{HirschbergDiff(-1, true), WagnerDiff(-1), MyersDiff(-1), DefaultDiff()} {
+			comp := comparable.NewString(a, b)
+			path := diff(comp)
+			intEqual(t, changeCount(path), exp, fmt.Sprint(`changes for `, a, ` and `, b))
+			checkValidPath(t, path, comp)
+		}
+		for _, diff := range []Algorithm{PatienceDiff(nil), HistogramDiff(-1, true, -1)} {
+			comp := comparable.NewString(a, b)
+			checkValidPath(t, diff(comp), comp)
+		}
+	}
+}
+
+// changeCount gets the number of removed and added parts in the given path.
+func changeCount(path Results) int {
+	count := 0
+	path.Read(func(stepType step.Type, c int) {
+		if stepType != step.Equal {
+			count += c
+		}
+	})
+	return count
+}
+
+// checkValidPath checks that the given path covers the given comparable
+// and that all the parts in the equal steps are equal.
+func checkValidPath(t *testing.T, path Results, comp comparable.Comparable) {
+	aIndex, bIndex := 0, 0
+	path.Read(func(stepType step.Type, count int) {
+		for i := 0; i < count; i++ {
+			switch stepType {
+			case step.Equal:
+				if aIndex >= comp.ALength() || bIndex >= comp.BLength() || !comp.Equals(aIndex, bIndex) {
+					t.Error("Path has an equal step which isn't equal at ", aIndex, ", ", bIndex)
+					return
+				}
+				aIndex++
+				bIndex++
+			case step.Added:
+				bIndex++
+			case step.Removed:
+				aIndex++
+			}
+		}
+	})
+	if aIndex != comp.ALength() || bIndex != comp.BLength() {
+		t.Error("Path doesn't cover the comparable: ", aIndex, ", ", bIndex)
+	}
+}
@@ -0,0 +1,178 @@
+package godiff
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Grant-Nelson/goDiff/comparable"
+	"github.com/Grant-Nelson/goDiff/internal/collector"
+)
+
+// lyingComparable is a malformed comparable which claims to be longer than it is.
+type lyingComparable struct {
+	comparable.Comparable
+}
+
+func (comp lyingComparable) ALength() int {
+	return comp.Comparable.ALength() + 3
+}
+
+// panickingComparable is a malformed comparable which panics when compared.
+type panickingComparable struct {
+	comparable.Comparable
+}
+
+func (comp panickingComparable) Equals(aIndex, bIndex int) bool {
+	panic(errors.New(`bad comparable`))
+}
+
+func Test_Diff_Failed(t *testing.T) {
+	for _, diff := range []Algorithm{
+		DefaultDiff(),
+		HirschbergDiff(-1, false),
+		WagnerDiff(-1),
+		MyersDiff(-1),
+		PatienceDiff(nil),
+		HistogramDiff(-1, true, DefaultWagnerThreshold),
+	} {
+		checkFailed(t, diff, lyingComparable{comparable.NewChar(`kitten`, `sitting`)},
+			`diff failed: runtime error: index out of range`)
+		checkFailed(t, diff, panickingComparable{comparable.NewChar(`kitten`, `sitting`)},
+			`diff failed: bad comparable`)
+	}
+
+	checkFailed(t, DefaultDiff(), nil, `diff failed: runtime error: invalid memory address or nil pointer dereference`)
+
+	_, err := DiffContext(context.Background(), panickingComparable{comparable.NewChar(`kitten`, `sitting`)})
+	if err == nil || err.Error() != `diff failed: bad comparable` {
+		t.Error("Unexpected error from a failed diff: ", err)
+	}
+}
+
+// checkFailed checks that the diff of the given comparable failed without panicking.
+func checkFailed(t *testing.T, diff Algorithm, comp comparable.Comparable, expErr string) {
+	path := diff(comp)
+	err := path.Err()
+	if err == nil || !strings.HasPrefix(err.Error(), expErr) || path.Count() != 0 || path.Total() != 0 {
+		t.Error("Algorithm returned unexpected result for a malformed comparable:",
+			"\n   Expected Error: ", expErr,
+			"\n   Result Error:   ", err,
+			"\n   Count:          ", path.Count(),
+			"\n   Total:          ", path.Total())
+	}
+}
+
+func Test_Diff_FailedCallers(t *testing.T) {
+	failing := func(comp comparable.Comparable) Results {
+		return DefaultDiff()(panickingComparable{comp})
+	}
+	a, b := lines(`kitten`, `mitten`), lines(`sitting`, `mitten`)
+	checkErr := func(err error, name string) {
+		if err == nil || err.Error() != `diff failed: bad comparable` {
+			t.Error("Unexpected error from ", name, ": ", err)
+		}
+	}
+
+	plusMinus, err := PlusMinusCustomErr(failing, a, b)
+	checkErr(err, `PlusMinusCustomErr`)
+	intEqual(t, len(plusMinus), 0, `PlusMinusCustomErr result length`)
+
+	unified, err := UnifiedCustomErr(failing, `a`, `b`, a, b, 3)
+	checkErr(err, `UnifiedCustomErr`)
+	strEqual(t, unified, ``, `UnifiedCustomErr result`)
+
+	text, err := DiffTextCustomErr(failing, `kitten`, `sitting`, nil)
+	checkErr(err, `DiffTextCustomErr`)
+	intEqual(t, len(text), 0, `DiffTextCustomErr result length`)
+
+	refined, err := RefineCustomErr(failing, a, b, RefineWords)
+	checkErr(err, `RefineCustomErr`)
+	intEqual(t, len(refined), 0, `RefineCustomErr result length`)
+
+	// The line diff succeeds but the diff of the changed block fails.
+	calls := 0
+	failingBlock := func(comp comparable.Comparable) Results {
+		calls++
+		if calls > 1 {
+			return failing(comp)
+		}
+		return DefaultDiff()(comp)
+	}
+	refined, err = RefineCustomErr(failingBlock, a, b, RefineWords)
+	checkErr(err, `RefineCustomErr for a block`)
+	intEqual(t, len(refined), 0, `RefineCustomErr for a block result length`)
+
+	merge := NewMerge(failing, a, b)
+	checkErr(merge.Err(), `NewMerge`)
+	intEqual(t, len(merge.Regions), 0, `NewMerge region count`)
+
+	merge = NewMerge3(lines(`mitten`), a, b, &MergeOptions{Diff: failing})
+	checkErr(merge.Err(), `NewMerge3`)
+	intEqual(t, len(merge.Regions), 0, `NewMerge3 region count`)
+
+	if err := NewMerge(nil, a, b).Err(); err != nil {
+		t.Error("Unexpected error from a merge which didn't fail: ", err)
+	}
+	if _, err := PlusMinusCustomErr(nil, a, b); err != nil {
+		t.Error("Unexpected error from a diff which didn't fail: ", err)
+	}
+}
+
+// lateFailingComparable is a malformed comparable which panics the second time the failing
+// indices are compared, so that it doesn't panic until the diff has been split. The second time
+// the slow indices are compared it sleeps, so that part of the diff is still running when it panics.
+// Once returned is set, any comparisons are counted in usedAfter.
+type lateFailingComparable struct {
+	comparable.Comparable
+	failA, failB int
+	slowA, slowB int
+
+	failed, slowed      int32
+	returned, usedAfter int32
+}
+
+func (comp *lateFailingComparable) Equals(aIndex, bIndex int) bool {
+	if atomic.LoadInt32(&comp.returned) > 0 {
+		atomic.AddInt32(&comp.usedAfter, 1)
+	}
+	if aIndex == comp.failA && bIndex == comp.failB && atomic.AddInt32(&comp.failed, 1) > 1 {
+		panic(errors.New(`bad comparable`))
+	}
+	if aIndex == comp.slowA && bIndex == comp.slowB && atomic.AddInt32(&comp.slowed, 1) > 1 {
+		time.Sleep(100 * time.Millisecond)
+	}
+	return comp.Comparable.Equals(aIndex, bIndex)
+}
+
+func Test_Diff_ParallelFailed(t *testing.T) {
+	good := comparable.NewChar(strings.Repeat(billNyeA, 6), strings.Repeat(billNyeB, 6))
+	exp := HirschbergDiff(-1, true)(good).(*collector.Collector).String()
+
+	// The first part of the first split is still being diffed by another worker when the last part
+	// panics. That worker must be finished before the diff returns and the workers are reused.
+	for _, newDiff := range []func() Algorithm{
+		func() Algorithm { return ParallelHirschbergDiff(-1, true, 4) },
+		func() Algorithm {
+			return PooledDiff(func() Algorithm { return ParallelHirschbergDiff(-1, true, 4) })
+		},
+	} {
+		diff := newDiff()
+		bad := &lateFailingComparable{
+			Comparable: comparable.NewChar(billNyeA, billNyeB),
+			failA:      len(billNyeA) - 20,
+			failB:      len(billNyeB) - 20,
+			slowA:      20,
+			slowB:      20,
+		}
+		checkFailed(t, diff, bad, `diff failed: bad comparable`)
+		atomic.StoreInt32(&bad.returned, 1)
+		strEqual(t, diff(good).(*collector.Collector).String(), exp, `diff after a failure`)
+		if used := atomic.LoadInt32(&bad.usedAfter); used > 0 {
+			t.Error("The failed comparable was compared ", used, " times after the diff returned")
+		}
+	}
+}
@@ -3,6 +3,7 @@ package godiff
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/Grant-Nelson/goDiff/comparable"
 	"github.com/Grant-Nelson/goDiff/step"
@@ -116,3 +117,161 @@ func ExampleDiff() {
 	// = 1
 	// + 1
 }
+
+func ExampleUnified() {
+	original := "Shopping List:\n" +
+		"Eggs\n" +
+		"Bacon\n" +
+		"Apples\n" +
+		"Oranges\n" +
+		"Milk"
+	changed := "Shopping List:\n" +
+		"Yogurt\n" +
+		"Apples\n" +
+		"Oranges\n" +
+		"Bananas\n" +
+		"Milk\n" +
+		"Tea\n"
+
+	originalLines := strings.Split(original, "\n")
+	changedLines := strings.Split(changed, "\n")
+	diff := Unified("a/list.txt", "b/list.txt", originalLines, changedLines, 3)
+
+	fmt.Print(diff)
+	// Output:
+	// --- a/list.txt
+	// +++ b/list.txt
+	// @@ -1,6 +1,7 @@
+	//  Shopping List:
+	// -Eggs
+	// -Bacon
+	// +Yogurt
+	//  Apples
+	//  Oranges
+	// -Milk
+	// \ No newline at end of file
+	// +Bananas
+	// +Milk
+	// +Tea
+}
+
+func ExamplePatch_Apply() {
+	original := strings.Split("Eggs\nBacon\nApples\nMilk", "\n")
+	changed := strings.Split("Yogurt\nApples\nMilk\nTea", "\n")
+
+	patch := DiffPatch(original, changed)
+	rebuilt, _ := patch.Apply(original)
+	fmt.Println(strings.Join(rebuilt, ", "))
+
+	reverted, _ := patch.Revert(changed)
+	fmt.Println(strings.Join(reverted, ", "))
+	// Output:
+	// Yogurt, Apples, Milk, Tea
+	// Eggs, Bacon, Apples, Milk
+}
+
+func ExampleParseDiff() {
+	text := "--- a/list.txt\n" +
+		"+++ b/list.txt\n" +
+		"@@ -1,3 +1,3 @@\n" +
+		" Eggs\n" +
+		"-Bacon\n" +
+		"+Yogurt\n" +
+		" Milk\n"
+
+	files, _ := ParseDiff(text)
+	for _, hunk := range files[0].Hunks {
+		hunk.Read(func(stepType step.Type, count int) {
+			fmt.Println(stepType, count)
+		})
+	}
+
+	patched, _ := files[0].Patch().Apply([]string{"Eggs", "Bacon", "Milk"})
+	fmt.Println(strings.Join(patched, ", "))
+	// Output:
+	// = 1
+	// - 1
+	// + 1
+	// = 1
+	// Eggs, Yogurt, Milk
+}
+
+func ExampleMerge3() {
+	base := strings.Split("Eggs\nBacon\nApples\nMilk", "\n")
+	ours := strings.Split("Eggs\nYogurt\nApples\nMilk", "\n")
+	theirs := strings.Split("Eggs\nBeans\nApples\nMilk\nTea", "\n")
+
+	merged := Merge3(base, ours, theirs)
+	fmt.Println(strings.Join(merged, "\n"))
+	// Output:
+	// Eggs
+	// <<<<<<<
+	// Yogurt
+	// =======
+	// Beans
+	// >>>>>>>
+	// Apples
+	// Milk
+	// Tea
+}
+
+func ExampleNewMerge3() {
+	base := strings.Split("Eggs\nBacon\nMilk", "\n")
+	ours := strings.Split("Eggs\nYogurt\nMilk", "\n")
+	theirs := strings.Split("Eggs\nBacon\nMilk\nTea", "\n")
+
+	result := NewMerge3(base, ours, theirs, nil)
+	for _, region := range result.Regions {
+		fmt.Println(region.Kind, region.Lines())
+	}
+	fmt.Println(result.HasConflicts())
+	// Output:
+	// unchanged [Eggs]
+	// from A [Yogurt]
+	// unchanged [Milk]
+	// from B [Tea]
+	// false
+}
+
+func ExampleRefine() {
+	original := []string{"Shopping List:", "Eggs and Bacon", "Milk"}
+	changed := []string{"Shopping List:", "Eggs and Beans", "Milk"}
+
+	for _, line := range Refine(original, changed, RefineWords) {
+		for _, span := range line.Spans {
+			fmt.Println(line.Type, line.Text[span.Start:span.End])
+		}
+	}
+	// Output:
+	// - Bacon
+	// + Beans
+}
+
+func ExamplePooledDiff() {
+	// The pooled diff can be shared by all the goroutines, such as HTTP handlers.
+	diff := PooledDiff(func() Algorithm {
+		return HybridDiff(-1, true, DefaultWagnerThreshold)
+	})
+
+	results := make([]string, 3)
+	wg := sync.WaitGroup{}
+	for i, changed := range []string{`sitting`, `kitchen`, `mitten`} {
+		wg.Add(1)
+		go func(i int, changed string) {
+			defer wg.Done()
+			edits := 0
+			diff(comparable.NewChar(`kitten`, changed)).Read(func(stepType step.Type, count int) {
+				if stepType != step.Equal {
+					edits += count
+				}
+			})
+			results[i] = fmt.Sprint(changed, `: `, edits, ` edits`)
+		}(i, changed)
+	}
+	wg.Wait()
+	fmt.Println(strings.Join(results, "\n"))
+
+	// Output: sitting: 5 edits
+	// kitchen: 3 edits
+	// mitten: 2 edits
+}
@@ -6,12 +6,12 @@ import (
 	"github.com/Grant-Nelson/goDiff/step"
 )
 
-const (
-	errInsertAfterFinish = `may not insert into a collector which has already been finished`
-	errFinishAfterFinish = `may not finish a collector which has already been finished`
-	errFinishBeforeCount = `may not request count until the collector has been finished`
-	errFinishBeforeTotal = `may not request total until the collector has been finished`
-	errFinishBeforeRead  = `may not request read until the collector has been finished`
+var (
+	errInsertAfterFinish = errors.New(`may not insert into a collector which has already been finished`)
+	errFinishAfterFinish = errors.New(`may not finish a collector which has already been finished`)
+	errFinishBeforeCount = errors.New(`may not request count until the collector has been finished`)
+	errFinishBeforeTotal = errors.New(`may not request total until the collector has been finished`)
+	errFinishBeforeRead  = errors.New(`may not request read until the collector has been finished`)
 )
 
 type (
@@ -54,19 +54,28 @@ type (
 
 		// finished indicates if the collector has had Finished called.
 		finished bool
+
+		// approximate indicates if any coarse parts were inserted,
+		// meaning the collection may not be the minimal path.
+		approximate bool
+
+		// err is the first error which occurred while using the collector.
+		err error
 	}
 )
 
 // New creates a new collector.
 func New() *Collector {
 	return &Collector{
-		head:       nil,
-		count:      0,
-		total:      0,
-		addedRun:   0,
-		removedRun: 0,
-		equalRun:   0,
-		finished:   false,
+		head:        nil,
+		count:       0,
+		total:       0,
+		addedRun:    0,
+		removedRun:  0,
+		equalRun:    0,
+		finished:    false,
+		approximate: false,
+		err:         nil,
 	}
 }
 
@@ -105,25 +114,41 @@ func (c *Collector) pushEqual() {
 	}
 }
 
-// panicIfFinished will panic with the given message if the collector has been finished.
-func (c *Collector) panicIfFinished(errMsg string) {
+// SetErr records the given error if no other error has been recorded yet.
+// Only the first error is kept since the following errors are usually caused by it.
+func (c *Collector) SetErr(err error) {
+	if c.err == nil {
+		c.err = err
+	}
+}
+
+// Err gets the first error which occurred while using the collector, or nil if none.
+func (c *Collector) Err() error {
+	return c.err
+}
+
+// errIfFinished will record the given error and return true if the collector has been finished.
+func (c *Collector) errIfFinished(err error) bool {
 	if c.finished {
-		panic(errors.New(errMsg))
+		c.SetErr(err)
+		return true
 	}
+	return false
 }
 
-// panicIfNotFinished will panic with the given message if the collector has not been finished.
-func (c *Collector) panicIfNotFinished(errMsg string) {
+// errIfNotFinished will record the given error and return true if the collector has not been finished.
+func (c *Collector) errIfNotFinished(err error) bool {
 	if !c.finished {
-		panic(errors.New(errMsg))
+		c.SetErr(err)
+		return true
 	}
+	return false
 }
 
 // InsertAdded inserts new Added parts into this collection.
 // This is expected to be inserted in reverse order from the expected result.
 func (c *Collector) InsertAdded(count int) {
-	c.panicIfFinished(errInsertAfterFinish)
-	if count > 0 {
+	if !c.errIfFinished(errInsertAfterFinish) && count > 0 {
 		c.pushEqual()
 		c.addedRun += count
 	}
@@ -132,8 +157,7 @@ func (c *Collector) InsertAdded(count int) {
 // InsertRemoved inserts new Removed parts into this collection.
 // This is expected to be inserted in reverse order from the expected result.
 func (c *Collector) InsertRemoved(count int) {
-	c.panicIfFinished(errInsertAfterFinish)
-	if count > 0 {
+	if !c.errIfFinished(errInsertAfterFinish) && count > 0 {
 		c.pushEqual()
 		c.removedRun += count
 	}
@@ -142,8 +166,7 @@ func (c *Collector) InsertRemoved(count int) {
 // InsertEqual inserts new Equal parts into this collection.
 // This is expected to be inserted in reverse order from the expected result.
 func (c *Collector) InsertEqual(count int) {
-	c.panicIfFinished(errInsertAfterFinish)
-	if count > 0 {
+	if !c.errIfFinished(errInsertAfterFinish) && count > 0 {
 		c.pushAdded()
 		c.pushRemoved()
 		c.equalRun += count
@@ -153,17 +176,45 @@ func (c *Collector) InsertEqual(count int) {
 // InsertSubstitute inserts new Added and Removed parts into this collection.
 // This is expected to be inserted in reverse order from the expected result.
 func (c *Collector) InsertSubstitute(count int) {
-	c.panicIfFinished(errInsertAfterFinish)
-	if count > 0 {
+	if !c.errIfFinished(errInsertAfterFinish) && count > 0 {
 		c.pushEqual()
 		c.addedRun += count
 		c.removedRun += count
 	}
 }
 
+// InsertCoarse inserts new Removed and Added parts into this collection
+// for a part of the diff which was not fully run, such as when the diff was canceled.
+// This marks the collection as approximate even if there are no parts to insert.
+// This is expected to be inserted in reverse order from the expected result.
+func (c *Collector) InsertCoarse(removed, added int) {
+	if c.errIfFinished(errInsertAfterFinish) {
+		return
+	}
+	c.InsertAdded(added)
+	c.InsertRemoved(removed)
+	c.approximate = true
+}
+
+// InsertStep inserts new parts of the given step type into this collection.
+// This is expected to be inserted in reverse order from the expected result.
+func (c *Collector) InsertStep(stepType step.Type, count int) {
+	switch stepType {
+	case step.Equal:
+		c.InsertEqual(count)
+	case step.Added:
+		c.InsertAdded(count)
+	case step.Removed:
+		c.InsertRemoved(count)
+	}
+}
+
 // Finish inserts any remaining parts which haven't been inserted yet.
+// If the collector has already been finished an error is recorded.
 func (c *Collector) Finish() {
-	c.panicIfFinished(errFinishAfterFinish)
+	if c.errIfFinished(errFinishAfterFinish) {
+		return
+	}
 	c.finished = true
 	c.pushAdded()
 	c.pushRemoved()
@@ -175,26 +226,36 @@ func (c *Collector) Finished() bool {
 	return c.finished
 }
 
+// Approximate indicates if any coarse parts were inserted,
+// meaning the collection may not be the minimal path.
+func (c *Collector) Approximate() bool {
+	return c.approximate
+}
+
 // Count is the number of steps that have been collected.
-// Finish should be called prior to this being used.
+// Finish should be called prior to this being used, otherwise an error is recorded and zero is returned.
 func (c *Collector) Count() int {
-	c.panicIfNotFinished(errFinishBeforeCount)
+	if c.errIfNotFinished(errFinishBeforeCount) {
+		return 0
+	}
 	return c.count
 }
 
 // Total is the total number of parts represented by this collection.
 // The total sum of all the counts in each step.
-// Finish should be called prior to this being used.
+// Finish should be called prior to this being used, otherwise an error is recorded and zero is returned.
 func (c *Collector) Total() int {
-	c.panicIfNotFinished(errFinishBeforeTotal)
+	if c.errIfNotFinished(errFinishBeforeTotal) {
+		return 0
+	}
 	return c.total
 }
 
 // Read will read the collected steps in the expected result order,
 // reversed from the order that it was inserted.
+// Finish should be called prior to this being used, otherwise an error is recorded and nothing is read.
 func (c *Collector) Read(hndl step.PathCallback) {
-	c.panicIfNotFinished(errFinishBeforeRead)
-	if hndl != nil {
+	if !c.errIfNotFinished(errFinishBeforeRead) && hndl != nil {
 		node := c.head
 		for node != nil {
 			hndl(node.step, node.count)
@@ -1,6 +1,7 @@
 package collector
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -38,6 +39,37 @@ func Test_Basics(t *testing.T) {
 	readEqual(t, col, `=5 -5 =4 +4 =3 -3 +3`)
 }
 
+func Test_InsertStep(t *testing.T) {
+	col := New()
+
+	col.InsertStep(step.Added, 1)
+	col.InsertStep(step.Removed, 2)
+	col.InsertStep(step.Equal, 3)
+	col.InsertStep(step.Added, 4)
+	col.InsertStep(step.Equal, 5)
+	col.InsertStep(step.Type(7), 6)
+	col.Finish()
+
+	readEqual(t, col, `=5 +4 =3 -2 +1`)
+}
+
+func Test_InsertCoarse(t *testing.T) {
+	col := New()
+	col.InsertEqual(1)
+	boolEqual(t, col.Approximate(), false, `Collection.Approximate`)
+	col.InsertCoarse(2, 3)
+	col.InsertEqual(4)
+	boolEqual(t, col.Approximate(), true, `Collection.Approximate`)
+	col.Finish()
+	readEqual(t, col, `=4 -2 +3 =1`)
+
+	col = New()
+	col.InsertCoarse(0, 0)
+	col.Finish()
+	boolEqual(t, col.Approximate(), true, `Collection.Approximate`)
+	readEqual(t, col, ``)
+}
+
 func Test_Error(t *testing.T) {
 	col := New()
 
@@ -48,9 +80,9 @@ func Test_Error(t *testing.T) {
 	col.InsertAdded(2)
 	col.InsertSubstitute(3)
 
-	panicEqual(t, func() { col.Count() }, errFinishBeforeCount, `Collection.Count`)
-	panicEqual(t, func() { col.Total() }, errFinishBeforeTotal, `Collection.Total`)
-	panicEqual(t, func() { col.Read(nil) }, errFinishBeforeRead, `Collection.Read`)
+	errEqual(t, col, func() { intEqual(t, col.Count(), 0, `Collection.Count`) }, errFinishBeforeCount, `Collection.Count`)
+	errEqual(t, col, func() { intEqual(t, col.Total(), 0, `Collection.Total`) }, errFinishBeforeTotal, `Collection.Total`)
+	errEqual(t, col, func() { col.Read(nil) }, errFinishBeforeRead, `Collection.Read`)
 
 	col.Finish()
 
@@ -58,11 +90,32 @@ func Test_Error(t *testing.T) {
 	intEqual(t, col.Total(), 15, `Collection.Total`)
 	readEqual(t, col, `-5 +5 =3 -1 +1`)
 
-	panicEqual(t, func() { col.Finish() }, errFinishAfterFinish, `Collection.Finish`)
-	panicEqual(t, func() { col.InsertAdded(4) }, errInsertAfterFinish, `Collection.InsertAdded`)
-	panicEqual(t, func() { col.InsertRemoved(4) }, errInsertAfterFinish, `Collection.InsertRemoved`)
-	panicEqual(t, func() { col.InsertEqual(4) }, errInsertAfterFinish, `Collection.InsertEqual`)
-	panicEqual(t, func() { col.InsertSubstitute(4) }, errInsertAfterFinish, `Collection.InsertSubstitute`)
+	errEqual(t, col, func() { col.Finish() }, errFinishAfterFinish, `Collection.Finish`)
+	errEqual(t, col, func() { col.InsertAdded(4) }, errInsertAfterFinish, `Collection.InsertAdded`)
+	errEqual(t, col, func() { col.InsertRemoved(4) }, errInsertAfterFinish, `Collection.InsertRemoved`)
+	errEqual(t, col, func() { col.InsertEqual(4) }, errInsertAfterFinish, `Collection.InsertEqual`)
+	errEqual(t, col, func() { col.InsertSubstitute(4) }, errInsertAfterFinish, `Collection.InsertSubstitute`)
+	errEqual(t, col, func() { col.InsertCoarse(4, 4) }, errInsertAfterFinish, `Collection.InsertCoarse`)
+
+	// The collection is not changed by the errors.
+	intEqual(t, col.Count(), 5, `Collection.Count`)
+	intEqual(t, col.Total(), 15, `Collection.Total`)
+	readEqual(t, col, `-5 +5 =3 -1 +1`)
+	boolEqual(t, col.Approximate(), false, `Collection.Approximate`)
+}
+
+func Test_SetErr(t *testing.T) {
+	col := New()
+	if col.Err() != nil {
+		t.Error("Expected no error on a new collector.")
+	}
+
+	first, second := errors.New(`first`), errors.New(`second`)
+	col.SetErr(first)
+	col.SetErr(second)
+	if col.Err() != first {
+		t.Error("Expected the first error to be kept: ", col.Err())
+	}
 }
 
 func Test_ForcePush(t *testing.T) {
@@ -105,22 +158,16 @@ func readEqual(t *testing.T, col *Collector, exp string) {
 	}
 }
 
-func panicEqual(t *testing.T, hndl func(), exp, msg string) {
-	value := func() (errMsg string) {
-		defer func() {
-			if r := recover(); r != nil {
-				errMsg = fmt.Sprint(r)
-			}
-		}()
-
-		hndl()
-
-		return `no panic occurred`
-	}()
-	if value != exp {
-		t.Error(fmt.Sprint("Unexpected panic message:",
+// errEqual checks that calling the given handle records the expected error.
+// The recorded error is cleared before and after the handle is called.
+func errEqual(t *testing.T, col *Collector, hndl func(), exp error, msg string) {
+	col.err = nil
+	hndl()
+	if value := col.Err(); value != exp {
+		t.Error(fmt.Sprint("Unexpected error:",
 			"\n   Message:  ", msg,
 			"\n   Value:    ", value,
 			"\n   Expected: ", exp))
 	}
+	col.err = nil
 }
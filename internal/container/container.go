@@ -1,24 +1,12 @@
 package container
 
 import (
+	"context"
+
 	"github.com/Grant-Nelson/goDiff/comparable"
 	"github.com/Grant-Nelson/goDiff/internal/collector"
 )
 
-const (
-	// RemoveCost gives the cost to remove A at the given index.
-	RemoveCost = 1
-
-	// AddCost gives the cost to add B at the given index.
-	AddCost = 1
-
-	// SubstitionCost gives the substition cost for replacing A with B at the given indices.
-	SubstitionCost = 2
-
-	// EqualCost gives the cost for A and B being equal.
-	EqualCost = 0
-)
-
 type (
 	// Diff is the interface for a diff algorithm.
 	Diff interface {
@@ -37,6 +25,9 @@ type (
 	// revered reading of the data in the comparable.
 	Container struct {
 		comp    comparable.Comparable
+		hash    comparable.Hashable
+		costs   *Costs
+		ctx     context.Context
 		aOffset int
 		aLength int
 		bOffset int
@@ -45,13 +36,16 @@ type (
 	}
 )
 
-// check that the container also implements the comparable.
-var _ comparable.Comparable = (*Container)(nil)
+// check that the container also implements the hashable comparable.
+var _ comparable.Hashable = (*Container)(nil)
 
 // newSub creates a new comparable for the given range.
-func newSub(comp comparable.Comparable, aOffset, aLength, bOffset, bLength int, reverse bool) *Container {
+func newSub(comp comparable.Comparable, hash comparable.Hashable, costs *Costs, ctx context.Context, aOffset, aLength, bOffset, bLength int, reverse bool) *Container {
 	return &Container{
 		comp:    comp,
+		hash:    hash,
+		costs:   costs,
+		ctx:     ctx,
 		aOffset: aOffset,
 		aLength: aLength,
 		bOffset: bOffset,
@@ -60,9 +54,37 @@ func newSub(comp comparable.Comparable, aOffset, aLength, bOffset, bLength int,
 	}
 }
 
-// New creates a new comparable for a full container.
+// hashableOf gets the hashable for the given comparable or nil if not hashable.
+// Containers are only hashable if the comparable they contain is hashable.
+func hashableOf(comp comparable.Comparable) comparable.Hashable {
+	if cont, ok := comp.(*Container); ok {
+		if cont.hash == nil {
+			return nil
+		}
+		return cont
+	}
+	hash, _ := comp.(comparable.Hashable)
+	return hash
+}
+
+// New creates a new comparable for a full container using the default costs.
 func New(comp comparable.Comparable) *Container {
-	return newSub(comp,
+	return NewCosts(comp, nil)
+}
+
+// NewCosts creates a new comparable for a full container using the given costs.
+// If the costs are nil then the default costs are used.
+func NewCosts(comp comparable.Comparable, costs *Costs) *Container {
+	return NewContext(context.Background(), comp, costs)
+}
+
+// NewContext creates a new comparable for a full container using the given costs
+// which can be canceled with the given context. If the costs are nil then the default costs are used.
+func NewContext(ctx context.Context, comp comparable.Comparable, costs *Costs) *Container {
+	if costs == nil {
+		costs = DefaultCosts()
+	}
+	return newSub(comp, hashableOf(comp), costs, ctx,
 		0, comp.ALength(),
 		0, comp.BLength(),
 		false)
@@ -78,6 +100,22 @@ func (cont *Container) BLength() int {
 	return cont.bLength
 }
 
+// AAdjust gets the A index adjusted by the container's condition.
+func (cont *Container) AAdjust(aIndex int) int {
+	if cont.reverse {
+		return cont.aLength - 1 - aIndex + cont.aOffset
+	}
+	return aIndex + cont.aOffset
+}
+
+// BAdjust gets the B index adjusted by the container's condition.
+func (cont *Container) BAdjust(bIndex int) int {
+	if cont.reverse {
+		return cont.bLength - 1 - bIndex + cont.bOffset
+	}
+	return bIndex + cont.bOffset
+}
+
 // Equals determines if the entries in the two given indices are equal.
 func (cont *Container) Equals(aIndex, bIndex int) bool {
 	if cont.reverse {
@@ -91,25 +129,50 @@ func (cont *Container) Equals(aIndex, bIndex int) bool {
 		bIndex+cont.bOffset)
 }
 
-// SubstitionCost determines the substition cost for the given indices.
-func (cont *Container) SubstitionCost(i, j int) int {
-	if cont.Equals(i, j) {
-		return EqualCost
-	}
-	return SubstitionCost
+// Context gets the context which the diff of this container can be canceled with.
+func (cont *Container) Context() context.Context {
+	return cont.ctx
+}
+
+// Canceled indicates if the diff of this container has been canceled.
+// When canceled the diff should stop and write a coarse result with Coarse.
+func (cont *Container) Canceled() bool {
+	return cont.ctx.Err() != nil
+}
+
+// Coarse writes the whole container as removed and added without looking
+// for any equal parts. This is used when the diff has been stopped early.
+func (cont *Container) Coarse(col *collector.Collector) {
+	col.InsertCoarse(cont.aLength, cont.bLength)
+}
+
+// Hashable indicates if the comparable in this container is hashable.
+// AHash and BHash may only be called if this returns true.
+func (cont *Container) Hashable() bool {
+	return cont.hash != nil
+}
+
+// AHash gets the hash of the entry in the first list at the given index.
+func (cont *Container) AHash(aIndex int) uint64 {
+	return cont.hash.AHash(cont.AAdjust(aIndex))
+}
+
+// BHash gets the hash of the entry in the second list at the given index.
+func (cont *Container) BHash(bIndex int) uint64 {
+	return cont.hash.BHash(cont.BAdjust(bIndex))
 }
 
 // Sub creates a new comparable container for a subset and reverse relative to this container's settings.
 // The high values are exclusive, the low is inclusive.
 func (cont *Container) Sub(aLow, aHigh, bLow, bHigh int, reverse bool) *Container {
 	if cont.reverse {
-		return newSub(cont.comp,
+		return newSub(cont.comp, cont.hash, cont.costs, cont.ctx,
 			cont.aLength-aHigh+cont.aOffset, aHigh-aLow,
 			cont.bLength-bHigh+cont.bOffset, bHigh-bLow,
 			!reverse)
 	}
 
-	return newSub(cont.comp,
+	return newSub(cont.comp, cont.hash, cont.costs, cont.ctx,
 		aLow+cont.aOffset, aHigh-aLow,
 		bLow+cont.bOffset, bHigh-bLow,
 		reverse)
@@ -135,7 +198,7 @@ func (cont *Container) Reduce() (*Container, int, int) {
 		}
 	}
 
-	sub := newSub(cont.comp,
+	sub := newSub(cont.comp, cont.hash, cont.costs, cont.ctx,
 		before+cont.aOffset, cont.aLength-after-before,
 		before+cont.bOffset, cont.bLength-after-before,
 		cont.reverse)
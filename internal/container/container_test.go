@@ -44,8 +44,8 @@ func Test_Equals(t *testing.T) {
 	boolEqual(t, cont.Equals(2, 2), true, `Equal(2, 2)`)
 	boolEqual(t, cont.Equals(2, 3), true, `Equal(2, 3)`)
 	boolEqual(t, cont.Equals(1, 2), false, `Equal(1, 2)`)
-	intEqual(t, cont.SubstitionCost(0, 0), SubstitionCost, `SubstitionCost(0, 0)`)
-	intEqual(t, cont.SubstitionCost(2, 2), EqualCost, `SubstitionCost(2, 2)`)
+	intEqual(t, cont.SubstitionCost(0, 0), DefaultSubstitionCost, `SubstitionCost(0, 0)`)
+	intEqual(t, cont.SubstitionCost(2, 2), DefaultEqualCost, `SubstitionCost(2, 2)`)
 }
 
 func Test_Equals_Reversed(t *testing.T) {
@@ -57,8 +57,30 @@ func Test_Equals_Reversed(t *testing.T) {
 	boolEqual(t, cont.Equals(0, 2), true, `Equal(0, 2)`)
 	boolEqual(t, cont.Equals(0, 3), true, `Equal(0, 3)`)
 	boolEqual(t, cont.Equals(1, 2), false, `Equal(1, 2)`)
-	intEqual(t, cont.SubstitionCost(0, 0), SubstitionCost, `SubstitionCost(0, 0)`)
-	intEqual(t, cont.SubstitionCost(0, 2), EqualCost, `SubstitionCost(2, 2)`)
+	intEqual(t, cont.SubstitionCost(0, 0), DefaultSubstitionCost, `SubstitionCost(0, 0)`)
+	intEqual(t, cont.SubstitionCost(0, 2), DefaultEqualCost, `SubstitionCost(2, 2)`)
+}
+
+func Test_Hash(t *testing.T) {
+	cont := newCont(`cat`, `kitten`)
+	boolEqual(t, cont.Hashable(), true, `Hashable`)
+	boolEqual(t, cont.AHash(2) == cont.BHash(2), true, `AHash(2) == BHash(2)`)
+	boolEqual(t, cont.AHash(2) == cont.BHash(3), true, `AHash(2) == BHash(3)`)
+	boolEqual(t, cont.AHash(0) == cont.BHash(0), false, `AHash(0) == BHash(0)`)
+
+	cont = reverse(cont).Sub(0, 2, 1, 4, false)
+	boolEqual(t, cont.Hashable(), true, `Hashable`)
+	boolEqual(t, cont.AHash(0) == cont.BHash(1), true, `AHash(0) == BHash(1)`)
+	boolEqual(t, cont.AHash(0) == cont.BHash(2), true, `AHash(0) == BHash(2)`)
+	boolEqual(t, cont.AHash(1) == cont.BHash(0), false, `AHash(1) == BHash(0)`)
+
+	nested := New(cont)
+	boolEqual(t, nested.Hashable(), true, `nested Hashable`)
+	boolEqual(t, nested.AHash(0) == nested.BHash(1), true, `nested AHash(0) == BHash(1)`)
+
+	cont = New(comparable.NewInterface([]interface{}{1}, []interface{}{1}, nil))
+	boolEqual(t, cont.Hashable(), false, `Interface Hashable`)
+	boolEqual(t, New(cont).Hashable(), false, `nested Interface Hashable`)
 }
 
 func Test_Sub(t *testing.T) {
@@ -131,6 +153,109 @@ func Test_EndCase(t *testing.T) {
 	endCaseCheck(t, newCont(`d`, `abc`), true, `-1 +3`)
 }
 
+func Test_Costs(t *testing.T) {
+	costs := DefaultCosts()
+	costs.RemoveFunc = func(aIndex int) int { return aIndex + 10 }
+	costs.AddFunc = func(bIndex int) int { return bIndex + 20 }
+	cont := NewCosts(comparable.NewChar(`abc`, `axc`), costs)
+	intEqual(t, cont.RemoveCost(0), 10, `remove 0`)
+	intEqual(t, cont.RemoveCost(2), 12, `remove 2`)
+	intEqual(t, cont.AddCost(1), 21, `add 1`)
+	intEqual(t, cont.SubstitionCost(0, 0), DefaultEqualCost, `substitute equal`)
+	intEqual(t, cont.SubstitionCost(1, 1), DefaultSubstitionCost, `substitute not equal`)
+
+	sub := cont.Sub(1, 3, 0, 2, true)
+	intEqual(t, sub.RemoveCost(0), 12, `reversed remove 0`)
+	intEqual(t, sub.AddCost(0), 21, `reversed add 0`)
+
+	costs.SubstitionFunc = func(aIndex, bIndex int) int { return aIndex*10 + bIndex }
+	intEqual(t, cont.SubstitionCost(1, 1), 11, `substitute func`)
+	intEqual(t, cont.SubstitionCost(2, 0), 20, `substitute func`)
+	intEqual(t, cont.SubstitionCost(2, 2), DefaultEqualCost, `substitute func equal`)
+}
+
+func Test_EndCase_Costs(t *testing.T) {
+	costs := DefaultCosts()
+	costs.AddFunc = func(bIndex int) int { return bIndex + 1 }
+	costs.RemoveFunc = func(aIndex int) int { return aIndex + 1 }
+	endCaseCheck(t, NewCosts(comparable.NewChar(`a`, `aba`), costs), true, `+2 =1`)
+	endCaseCheck(t, NewCosts(comparable.NewChar(`aba`, `a`), costs), true, `-2 =1`)
+	endCaseCheck(t, NewCosts(comparable.NewChar(`a`, `bcd`), costs), true, `-1 +3`)
+
+	costs.AddFunc = func(bIndex int) int { return 0 }
+	costs.RemoveFunc = func(aIndex int) int { return 0 }
+	endCaseCheck(t, NewCosts(comparable.NewChar(`a`, `aba`), costs), true, `-1 +3`)
+	endCaseCheck(t, NewCosts(comparable.NewChar(`aba`, `a`), costs), true, `-3 +1`)
+}
+
+func Test_EndCase_Affine(t *testing.T) {
+	costs := DefaultCosts()
+	costs.GapOpen = 3
+	endCaseCheck(t, NewCosts(comparable.NewChar(`c`, `acac`), costs), true, `+3 =1`)
+	endCaseCheck(t, NewCosts(comparable.NewChar(`acac`, `c`), costs), true, `-3 =1`)
+	endCaseCheck(t, NewCosts(comparable.NewChar(`a`, `bab`), costs), true, `+1 =1 +1`)
+	endCaseCheck(t, NewCosts(comparable.NewChar(`a`, `abca`), costs), true, `=1 +3`)
+}
+
+func Test_Stack(t *testing.T) {
+	s := NewStack()
+	intEqual(t, countNodes(s.top), 0, `top count`)
+	intEqual(t, countNodes(s.graveyard), 0, `graveyard count`)
+	boolEqual(t, s.NotEmpty(), false, `not empty`)
+
+	s.Push(nil, 1)
+	intEqual(t, countNodes(s.top), 1, `top count`)
+	intEqual(t, countNodes(s.graveyard), 0, `graveyard count`)
+	boolEqual(t, s.NotEmpty(), true, `not empty`)
+
+	s.Push(nil, 2)
+	intEqual(t, countNodes(s.top), 2, `top count`)
+	intEqual(t, countNodes(s.graveyard), 0, `graveyard count`)
+	boolEqual(t, s.NotEmpty(), true, `not empty`)
+
+	s.Push(nil, 3)
+	intEqual(t, countNodes(s.top), 3, `top count`)
+	intEqual(t, countNodes(s.graveyard), 0, `graveyard count`)
+	boolEqual(t, s.NotEmpty(), true, `not empty`)
+
+	_, remainder := s.Pop()
+	intEqual(t, remainder, 3, `popped remainder`)
+	intEqual(t, countNodes(s.top), 2, `top count`)
+	intEqual(t, countNodes(s.graveyard), 1, `graveyard count`)
+	boolEqual(t, s.NotEmpty(), true, `not empty`)
+
+	_, remainder = s.Pop()
+	intEqual(t, remainder, 2, `popped remainder`)
+	intEqual(t, countNodes(s.top), 1, `top count`)
+	intEqual(t, countNodes(s.graveyard), 2, `graveyard count`)
+	boolEqual(t, s.NotEmpty(), true, `not empty`)
+
+	_, remainder = s.Pop()
+	intEqual(t, remainder, 1, `popped remainder`)
+	intEqual(t, countNodes(s.top), 0, `top count`)
+	intEqual(t, countNodes(s.graveyard), 3, `graveyard count`)
+	boolEqual(t, s.NotEmpty(), false, `not empty`)
+
+	_, remainder = s.Pop()
+	intEqual(t, remainder, 0, `popped remainder`)
+	intEqual(t, countNodes(s.top), 0, `top count`)
+	intEqual(t, countNodes(s.graveyard), 3, `graveyard count`)
+	boolEqual(t, s.NotEmpty(), false, `not empty`)
+
+	s.Push(nil, 5)
+	intEqual(t, countNodes(s.top), 1, `top count`)
+	intEqual(t, countNodes(s.graveyard), 2, `graveyard count`)
+	boolEqual(t, s.NotEmpty(), true, `not empty`)
+}
+
+func countNodes(node *stackNode) int {
+	count := 0
+	for ; node != nil; count++ {
+		node = node.prev
+	}
+	return count
+}
+
 func checkMin2(t *testing.T, a, b, exp int) {
 	if result := Min2(a, b); result != exp {
 		t.Error(fmt.Sprint(
@@ -0,0 +1,129 @@
+package container
+
+const (
+	// DefaultRemoveCost gives the default cost to remove A at the given index.
+	DefaultRemoveCost = 1
+
+	// DefaultAddCost gives the default cost to add B at the given index.
+	DefaultAddCost = 1
+
+	// DefaultSubstitionCost gives the default substition cost for replacing A with B at the given indices.
+	DefaultSubstitionCost = 2
+
+	// DefaultEqualCost gives the default cost for A and B being equal.
+	DefaultEqualCost = 0
+
+	// DefaultGapOpenCost gives the default cost for starting a run of removes or adds.
+	// By default there is no cost so the costs are linear.
+	DefaultGapOpenCost = 0
+
+	// Unreachable is a cost used for paths which can not be taken.
+	// It is large enough to never be chosen but small enough to add costs to without overflowing.
+	Unreachable = int(^uint(0)>>1) / 4
+)
+
+// Costs is the configuration for how much each kind of edit costs.
+// The diff algorithms which use these costs find the path with the lowest total cost.
+//
+// The cost functions are optional and, if not nil, are used instead of the
+// matching constant cost. The indices given to the functions are the indices
+// into the comparable being diffed.
+type Costs struct {
+
+	// Remove is the cost to remove a part of A.
+	Remove int
+
+	// Add is the cost to add a part of B.
+	Add int
+
+	// Substition is the cost to replace a part of A with a part of B
+	// when the parts are not equal. This should be no more than
+	// the cost to remove and add otherwise it will never be used.
+	Substition int
+
+	// Equal is the cost to keep a part of A which is equal to the part of B.
+	Equal int
+
+	// GapOpen is the extra cost to start a run of removes or a run of adds.
+	// When this is greater than zero the costs are affine, meaning the Remove and Add
+	// costs are the cost to extend a run. This groups the edits into fewer, larger blocks.
+	// A substitution is not part of a run, so to group substitutions with the other edits
+	// the Substition cost should be at least Remove + Add + 2 * GapOpen.
+	GapOpen int
+
+	// RemoveFunc is the optional cost to remove the part of A at the given index.
+	RemoveFunc func(aIndex int) int
+
+	// AddFunc is the optional cost to add the part of B at the given index.
+	AddFunc func(bIndex int) int
+
+	// SubstitionFunc is the optional cost to replace the part of A at the given index
+	// with the part of B at the given index when the parts are not equal.
+	SubstitionFunc func(aIndex, bIndex int) int
+}
+
+// DefaultCosts creates a new set of costs with the default values.
+// These are the costs used for the Levenshtein distance.
+func DefaultCosts() *Costs {
+	return &Costs{
+		Remove:     DefaultRemoveCost,
+		Add:        DefaultAddCost,
+		Substition: DefaultSubstitionCost,
+		Equal:      DefaultEqualCost,
+		GapOpen:    DefaultGapOpenCost,
+	}
+}
+
+// uniform indicates if the costs are the same regardless of the index.
+func (costs *Costs) uniform() bool {
+	return costs.RemoveFunc == nil && costs.AddFunc == nil && costs.SubstitionFunc == nil
+}
+
+// Uniform indicates if the costs are the same regardless of the index,
+// meaning none of the cost functions are set.
+func (cont *Container) Uniform() bool {
+	return cont.costs.uniform()
+}
+
+// Costs gets the costs which this container is diffed with.
+// The returned costs must not be modified.
+func (cont *Container) Costs() *Costs {
+	return cont.costs
+}
+
+// Affine indicates if the costs have a gap open cost.
+func (cont *Container) Affine() bool {
+	return cont.costs.GapOpen > 0
+}
+
+// GapOpenCost determines the extra cost to start a run of removes or adds.
+func (cont *Container) GapOpenCost() int {
+	return cont.costs.GapOpen
+}
+
+// RemoveCost determines the cost to remove A at the given index.
+func (cont *Container) RemoveCost(aIndex int) int {
+	if cont.costs.RemoveFunc != nil {
+		return cont.costs.RemoveFunc(cont.AAdjust(aIndex))
+	}
+	return cont.costs.Remove
+}
+
+// AddCost determines the cost to add B at the given index.
+func (cont *Container) AddCost(bIndex int) int {
+	if cont.costs.AddFunc != nil {
+		return cont.costs.AddFunc(cont.BAdjust(bIndex))
+	}
+	return cont.costs.Add
+}
+
+// SubstitionCost determines the substition cost for the given indices.
+func (cont *Container) SubstitionCost(aIndex, bIndex int) int {
+	if cont.Equals(aIndex, bIndex) {
+		return cont.costs.Equal
+	}
+	if cont.costs.SubstitionFunc != nil {
+		return cont.costs.SubstitionFunc(cont.AAdjust(aIndex), cont.BAdjust(bIndex))
+	}
+	return cont.costs.Substition
+}
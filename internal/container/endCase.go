@@ -50,14 +50,7 @@ func (cont *Container) aEdge(col *collector.Collector) {
 		return
 	}
 
-	split := -1
-	for j := 0; j < bLen; j++ {
-		if cont.Equals(0, j) { // TODO: Optimise this scan.
-			split = j
-			break
-		}
-	}
-
+	split := cont.aSplit()
 	if split < 0 {
 		col.InsertAdded(bLen)
 		col.InsertRemoved(1)
@@ -77,14 +70,7 @@ func (cont *Container) bEdge(col *collector.Collector) {
 		return
 	}
 
-	split := -1
-	for i := 0; i < aLen; i++ {
-		if cont.Equals(i, 0) { // TODO: Optimise this scan.
-			split = i
-			break
-		}
-	}
-
+	split := cont.bSplit()
 	if split < 0 {
 		col.InsertAdded(1)
 		col.InsertRemoved(aLen)
@@ -94,3 +80,76 @@ func (cont *Container) bEdge(col *collector.Collector) {
 		col.InsertRemoved(split)
 	}
 }
+
+// aSplit finds the index of the B part to keep equal to the only A part,
+// or -1 if the A part should be removed.
+//
+// When the costs don't depend on the index and aren't affine the first equal B part is used.
+// Otherwise the equal B part with the lowest cost is used.
+func (cont *Container) aSplit() int {
+	bLen := cont.bLength
+	if cont.costs.uniform() && !cont.Affine() {
+		for j := 0; j < bLen; j++ {
+			if cont.Equals(0, j) { // TODO: Optimise this scan.
+				return j
+			}
+		}
+		return -1
+	}
+
+	// The costs are relative to adding all of B.
+	gapOpen := cont.GapOpenCost()
+	split, minCost := -1, cont.RemoveCost(0)+gapOpen*2
+	for j := 0; j < bLen; j++ {
+		if cont.Equals(0, j) {
+			cost := cont.costs.Equal - cont.AddCost(j) + splitGaps(j, bLen)*gapOpen
+			if cost < minCost {
+				split, minCost = j, cost
+			}
+		}
+	}
+	return split
+}
+
+// bSplit finds the index of the A part to keep equal to the only B part,
+// or -1 if the B part should be added.
+//
+// When the costs don't depend on the index and aren't affine the first equal A part is used.
+// Otherwise the equal A part with the lowest cost is used.
+func (cont *Container) bSplit() int {
+	aLen := cont.aLength
+	if cont.costs.uniform() && !cont.Affine() {
+		for i := 0; i < aLen; i++ {
+			if cont.Equals(i, 0) { // TODO: Optimise this scan.
+				return i
+			}
+		}
+		return -1
+	}
+
+	// The costs are relative to removing all of A.
+	gapOpen := cont.GapOpenCost()
+	split, minCost := -1, cont.AddCost(0)+gapOpen*2
+	for i := 0; i < aLen; i++ {
+		if cont.Equals(i, 0) {
+			cost := cont.costs.Equal - cont.RemoveCost(i) + splitGaps(i, aLen)*gapOpen
+			if cost < minCost {
+				split, minCost = i, cost
+			}
+		}
+	}
+	return split
+}
+
+// splitGaps is the number of runs left when the part at the given index
+// is kept equal out of the given number of parts.
+func splitGaps(index, length int) int {
+	gaps := 0
+	if index > 0 {
+		gaps++
+	}
+	if index < length-1 {
+		gaps++
+	}
+	return gaps
+}
@@ -1,13 +1,11 @@
-package hirschberg
-
-import "github.com/Grant-Nelson/goDiff/internal/container"
+package container
 
 type (
 	// stackNode is a node in a stack of containers.
 	stackNode struct {
 
 		// cont is the container for this node.
-		cont *container.Container
+		cont *Container
 
 		// remainder is any equals left over from the reduction.
 		remainder int
@@ -42,7 +40,7 @@ func (s *Stack) NotEmpty() bool {
 }
 
 // Push a new container onto this stack.
-func (s *Stack) Push(cont *container.Container, remainder int) {
+func (s *Stack) Push(cont *Container, remainder int) {
 	if (cont != nil) || (remainder > 0) {
 		if s.graveyard != nil {
 			node := s.graveyard
@@ -62,7 +60,7 @@ func (s *Stack) Push(cont *container.Container, remainder int) {
 }
 
 // Pop a container off the stack, will be nil if empty.
-func (s *Stack) Pop() (*container.Container, int) {
+func (s *Stack) Pop() (*Container, int) {
 	node := s.top
 	if node != nil {
 		s.top = node.prev
@@ -8,22 +8,6 @@ import (
 	"github.com/Grant-Nelson/goDiff/comparable"
 )
 
-// AAdjust gets the A index adjusted by the container's condition.
-func (cont *Container) AAdjust(aIndex int) int {
-	if cont.reverse {
-		return cont.aLength - 1 - aIndex + cont.aOffset
-	}
-	return aIndex + cont.aOffset
-}
-
-// BAdjust gets the B index adjusted by the container's condition.
-func (cont *Container) BAdjust(bIndex int) int {
-	if cont.reverse {
-		return cont.bLength - 1 - bIndex + cont.bOffset
-	}
-	return bIndex + cont.bOffset
-}
-
 // AParts gets the strings from the comparable which are
 // represented by this comparable.
 // This only works for String or RuneSlice comparables.
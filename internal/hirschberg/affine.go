@@ -0,0 +1,97 @@
+package hirschberg
+
+import (
+	"github.com/Grant-Nelson/goDiff/internal/container"
+)
+
+// allocateGapVectors will create the slices used for the affine remove score vectors.
+func (s *scores) allocateGapVectors(length int) {
+	s.gapFront = make([]int, length)
+	s.gapBack = make([]int, length)
+	s.gapOther = make([]int, length)
+}
+
+// calculateAffine calculates the Gotoh score with linear space.
+// At the end of this calculation the score is in the back vector
+// and the score for paths ending in a remove is in the gap back vector.
+// If the container is canceled the calculation is stopped early.
+func (s *scores) calculateAffine(cont *container.Container) {
+	aLen := cont.ALength()
+	bLen := cont.BLength()
+	if len(s.back) < bLen+1 {
+		s.allocateVectors(bLen + 1)
+	}
+	if len(s.gapBack) < bLen+1 {
+		s.allocateGapVectors(bLen + 1)
+	}
+	gapOpen := cont.GapOpenCost()
+
+	s.back[0] = 0
+	s.gapBack[0] = container.Unreachable
+	for j, add := 1, container.Unreachable; j <= bLen; j++ {
+		add = container.Min2(add, s.back[j-1]+gapOpen) + cont.AddCost(j-1)
+		s.back[j] = add
+		s.gapBack[j] = container.Unreachable
+	}
+
+	for i := 1; i <= aLen; i++ {
+		if cont.Canceled() {
+			return
+		}
+
+		removeCost := cont.RemoveCost(i - 1)
+		s.gapFront[0] = container.Min2(s.gapBack[0], s.back[0]+gapOpen) + removeCost
+		s.front[0] = s.gapFront[0]
+		for j, add := 1, container.Unreachable; j <= bLen; j++ {
+			remove := container.Min2(s.gapBack[j], s.back[j]+gapOpen) + removeCost
+			add = container.Min2(add, s.front[j-1]+gapOpen) + cont.AddCost(j-1)
+			s.gapFront[j] = container.Min2(remove, container.Unreachable)
+			s.front[j] = container.Min3(
+				s.back[j-1]+cont.SubstitionCost(i-1, j-1),
+				s.gapFront[j],
+				container.Min2(add, container.Unreachable))
+		}
+
+		s.swap()
+		s.gapBack, s.gapFront = s.gapFront, s.gapBack
+	}
+}
+
+// findAffinePivot finds the pivot between the given forward scores and the reverse of the back scores.
+// The pivot is the index of the minimum sum of each element in the two scores, or the
+// minimum sum of the remove scores less the gap open cost for a run of removes crossing the split.
+// Returns true if the pivot is for a run of removes crossing the split.
+func (s *scores) findAffinePivot(forward, gapForward []int, bLength, gapOpen int) (int, bool) {
+	index, crossing := 0, false
+	min := forward[0] + s.back[bLength]
+	for j := 0; j <= bLength; j++ {
+		if value := forward[j] + s.back[bLength-j]; value < min {
+			min, index, crossing = value, j, false
+		}
+		if value := gapForward[j] + s.gapBack[bLength-j] - gapOpen; value < min {
+			min, index, crossing = value, j, true
+		}
+	}
+	return index, crossing
+}
+
+// affineSplit will find the A and B mid points to split the container at using affine costs.
+// Returns true if a run of removes crosses the split, meaning the parts of A
+// on either side of the A mid point are removed.
+//
+// The halves are diffed separately so a run of adds or removes which is
+// continued on the other side of a split will be charged a second gap open cost.
+// This means the result may cost slightly more than the optimal affine path.
+func (s *scores) affineSplit(cont *container.Container, helper *scores) (int, int, bool) {
+	aLen := cont.ALength()
+	bLen := cont.BLength()
+
+	aMid := aLen / 2
+	forward, gapForward := s.calculateHalves(
+		cont.Sub(0, aMid, 0, bLen, false),
+		cont.Sub(aMid, aLen, 0, bLen, true),
+		helper, true)
+	bMid, crossing := s.findAffinePivot(forward, gapForward, bLen, cont.GapOpenCost())
+
+	return aMid, bMid, crossing
+}
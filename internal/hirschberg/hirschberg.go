@@ -8,10 +8,20 @@ import (
 // hirschberg will perform a Hirschberg with an optional hybrid diff on the given comparable.
 // The base algorithm is a Hirschberg's algorithm (https://en.wikipedia.org/wiki/Hirschberg%27s_algorithm)
 // used to divide the problem space until the threshold is reached to switch to the hybrid (usually Wagner).
+// When the costs are affine the score vectors use Gotoh's algorithm to find each split.
 type hirschberg struct {
 	scores    *scores
 	hybrid    container.Diff
 	useReduce bool
+	budget    int
+
+	// helper is the second set of score vectors used to calculate both halves of
+	// a large split at the same time. This is nil when the halves aren't calculated concurrently.
+	helper *scores
+
+	// idle are the idle workers which may help with a split when diffing in parallel.
+	// This is nil when not diffing in parallel.
+	idle chan *hirschberg
 }
 
 // New creates a new Hirschberg diff algorithm.
@@ -27,10 +37,40 @@ type hirschberg struct {
 // The useReduce flag indicates if the equal padding edges should be checked
 // at each step of the algorithm or not.
 func New(hybrid container.Diff, length int, useReduce bool) container.Diff {
+	return NewBudget(hybrid, length, useReduce, -1)
+}
+
+// NewBudget creates a new Hirschberg diff algorithm with an operation budget.
+//
+// The given budget is the maximum number of score and matrix entries which may be calculated
+// for each diff. Each split, and each use of the hybrid, of a container costs the A length
+// times the B length of that container. Once the budget would be exceeded, that container
+// and all the remaining containers are written as removed and added without being diffed
+// and the results are marked as approximate. Use -1 to not limit the operations.
+//
+// The hybrid, length, and useReduce are the same as for New.
+func NewBudget(hybrid container.Diff, length int, useReduce bool, budget int) container.Diff {
+	return &hirschberg{
+		scores:    newScores(length),
+		hybrid:    hybrid,
+		useReduce: useReduce,
+		budget:    budget,
+	}
+}
+
+// NewConcurrentSplit creates a new Hirschberg diff algorithm which calculates the scores
+// for both halves of each large split at the same time in two goroutines. This allocates
+// a second set of score vectors to calculate the other half with. The results are the same as New.
+// The comparable being diffed must be safe to read from multiple goroutines.
+//
+// The hybrid, length, and useReduce are the same as for New.
+func NewConcurrentSplit(hybrid container.Diff, length int, useReduce bool) container.Diff {
 	return &hirschberg{
 		scores:    newScores(length),
 		hybrid:    hybrid,
 		useReduce: useReduce,
+		budget:    -1,
+		helper:    newScores(length),
 	}
 }
 
@@ -45,7 +85,8 @@ func (h *hirschberg) NoResizeNeeded(cont *container.Container) bool {
 // Diff performs the algorithm on the given container
 // and writes the results to the collector.
 func (h *hirschberg) Diff(cont *container.Container, col *collector.Collector) {
-	stack := NewStack()
+	remaining := h.budget
+	stack := container.NewStack()
 	stack.Push(cont, 0)
 
 	for stack.NotEmpty() {
@@ -55,6 +96,11 @@ func (h *hirschberg) Diff(cont *container.Container, col *collector.Collector) {
 			continue
 		}
 
+		if cur.Canceled() {
+			cur.Coarse(col)
+			continue
+		}
+
 		if h.useReduce {
 			var before, after int
 			cur, before, after = cur.Reduce()
@@ -62,18 +108,72 @@ func (h *hirschberg) Diff(cont *container.Container, col *collector.Collector) {
 			stack.Push(nil, before)
 		}
 
-		if cur.EndCase(col) {
-			continue
+		for _, part := range h.divide(cur, col, &remaining) {
+			stack.Push(part, 0)
 		}
+	}
+}
 
-		if (h.hybrid != nil) && h.hybrid.NoResizeNeeded(cur) {
-			h.hybrid.Diff(cur, col)
-			continue
+// divide takes one step of the algorithm on the given reduced container. If the container
+// doesn't need to be split, the results are written to the collector and nil is returned.
+// Otherwise the container is split and the parts, in order, which still need to be diffed are returned.
+// The given remaining budget is reduced by the operations used, it is ignored if there is no budget.
+func (h *hirschberg) divide(cont *container.Container, col *collector.Collector, remaining *int) []*container.Container {
+	if cont.EndCase(col) {
+		return nil
+	}
+
+	if h.budget >= 0 {
+		operations := cont.ALength() * cont.BLength()
+		if operations > *remaining {
+			*remaining = 0
+			cont.Coarse(col)
+			return nil
+		}
+		*remaining -= operations
+	}
+
+	if (h.hybrid != nil) && h.hybrid.NoResizeNeeded(cont) {
+		h.hybrid.Diff(cont, col)
+		return nil
+	}
+
+	aLen, bLen := cont.ALength(), cont.BLength()
+	aMid, bMid, crossing := h.split(cont)
+	if cont.Canceled() {
+		cont.Coarse(col)
+		return nil
+	}
+
+	if crossing {
+		// Keep the removes on both sides of the split together.
+		return []*container.Container{
+			cont.Sub(0, aMid-1, 0, bMid, false),
+			cont.Sub(aMid-1, aMid+1, bMid, bMid, false),
+			cont.Sub(aMid+1, aLen, bMid, bLen, false),
 		}
+	}
 
-		aLen, bLen := cur.ALength(), cur.BLength()
-		aMid, bMid := h.scores.Split(cur)
-		stack.Push(cur.Sub(0, aMid, 0, bMid, false), 0)
-		stack.Push(cur.Sub(aMid, aLen, bMid, bLen, false), 0)
+	return []*container.Container{
+		cont.Sub(0, aMid, 0, bMid, false),
+		cont.Sub(aMid, aLen, bMid, bLen, false),
+	}
+}
+
+// split finds the A and B mid points to split the given container at. If the container is
+// large enough and there is a helper, or when diffing in parallel there is an idle worker, the helper's
+// or idle worker's score vectors are used to calculate the scores for both halves at the same time.
+func (h *hirschberg) split(cont *container.Container) (int, int, bool) {
+	if cont.ALength()*cont.BLength() >= parallelCutoff {
+		if h.helper != nil {
+			return h.scores.Split(cont, h.helper)
+		}
+		select {
+		case helper := <-h.idle:
+			defer func() { h.idle <- helper }()
+			return h.scores.Split(cont, helper.scores)
+		default:
+		}
 	}
+	return h.scores.Split(cont, nil)
 }
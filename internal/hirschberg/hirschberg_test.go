@@ -1,7 +1,9 @@
 package hirschberg
 
 import (
+	"context"
 	"fmt"
+	"math/rand"
 	"testing"
 
 	"github.com/Grant-Nelson/goDiff/comparable"
@@ -27,76 +29,193 @@ func Test_Hirschberg_Hybrid(t *testing.T) {
 		`=1 -4 +2 =5 -4 +2 =5 -4 +2 =3`)
 }
 
-func Test_Stack(t *testing.T) {
-	s := NewStack()
-	intEqual(t, countNodes(s.top), 0, `top count`)
-	intEqual(t, countNodes(s.graveyard), 0, `graveyard count`)
-	boolEqual(t, s.NotEmpty(), false, `not empty`)
+func checkAll(t *testing.T, d container.Diff) {
+	check(t, d, `A`, `A`, `=1`)
+	check(t, d, `A`, `B`, `-1 +1`)
+	check(t, d, `A`, `AB`, `=1 +1`)
+	check(t, d, `A`, `BA`, `+1 =1`)
+	check(t, d, `AB`, `A`, `=1 -1`)
+	check(t, d, `BA`, `A`, `-1 =1`)
+	check(t, d, `kitten`, `sitting`, `-1 +1 =3 -1 +1 =1 +1`)
+	check(t, d, `saturday`, `sunday`, `=1 -2 =1 -1 +1 =3`)
+	check(t, d, `satxrday`, `sunday`, `=1 -4 +2 =3`)
+	check(t, d, `ABC`, `ADB`, `=1 +1 =1 -1`)
+}
 
-	s.Push(nil, 1)
-	intEqual(t, countNodes(s.top), 1, `top count`)
-	intEqual(t, countNodes(s.graveyard), 0, `graveyard count`)
-	boolEqual(t, s.NotEmpty(), true, `not empty`)
+func Test_Hirschberg_Costs(t *testing.T) {
+	costs := container.DefaultCosts()
+	costs.RemoveFunc = func(aIndex int) int {
+		if aIndex == 2 {
+			return 10
+		}
+		return 1
+	}
+	cheapX := container.DefaultCosts()
+	cheapX.Add, cheapX.Remove, cheapX.Substition = 5, 5, 10
+	cheapX.RemoveFunc = func(aIndex int) int {
+		if aIndex%2 == 1 {
+			return 0
+		}
+		return 5
+	}
+	checkCosts(t, New(nil, -1, false), `ABC`, `ADB`, costs, `=1 -2 +2`)
+	checkCosts(t, New(nil, -1, false), `AxBxC`, `ABC`, cheapX, `=1 -1 =1 -1 =1`)
+	checkCosts(t, New(nil, -1, false), `BA`, `AB`, cheapX, `+1 =1 -1`)
+	checkCosts(t, New(nil, -1, true), `ABC`, `ADB`, costs, `=1 -2 +2`)
+	checkCosts(t, New(nil, -1, true), `AxBxC`, `ABC`, cheapX, `=1 -1 =1 -1 =1`)
+	checkCosts(t, New(nil, -1, true), `BA`, `AB`, cheapX, `+1 =1 -1`)
+}
 
-	s.Push(nil, 2)
-	intEqual(t, countNodes(s.top), 2, `top count`)
-	intEqual(t, countNodes(s.graveyard), 0, `graveyard count`)
-	boolEqual(t, s.NotEmpty(), true, `not empty`)
+func Test_Hirschberg_Affine(t *testing.T) {
+	costs := container.DefaultCosts()
+	costs.GapOpen = 3
+	costs.Substition = 8
+	checkCosts(t, New(nil, -1, false), `ABxxxxCD`, `ABCD`, costs, `=2 -4 =2`)
+	checkCosts(t, New(nil, -1, false), `AxBxxxxCxD`, `ABCD`, costs, `=1 -1 =1 -4 =1 -1 =1`)
+	checkCosts(t, New(nil, -1, false), `kitten`, `sitting`, costs, `-6 +7`)
+	checkCosts(t, New(nil, -1, false), `ABCDEFG`, `AxCxExG`, costs, `=1 -5 +5 =1`)
+	checkCosts(t, New(nil, -1, false), `ABxCDxEF`, `ABCDEF`, costs, `=2 -1 =2 -1 =2`)
+	checkCosts(t, New(nil, -1, true), `kitten`, `sitting`, costs, `-6 +7`)
+	checkCosts(t, New(nil, -1, true), `ABCDEFG`, `AxCxExG`, costs, `=1 -5 +5 =1`)
+	checkCosts(t, New(nil, -1, true), `ABxCDxEF`, `ABCDEF`, costs, `=2 -1 =2 -1 =2`)
+}
 
-	s.Push(nil, 3)
-	intEqual(t, countNodes(s.top), 3, `top count`)
-	intEqual(t, countNodes(s.graveyard), 0, `graveyard count`)
-	boolEqual(t, s.NotEmpty(), true, `not empty`)
+func Test_Hirschberg_Budget(t *testing.T) {
+	checkBudget(t, NewBudget(nil, -1, false, -1), `kitten kitten`, `sitting sitting`,
+		`-1 +1 =3 -1 +1 =1 +1 =1 -1 +1 =3 -1 +1 =1 +1`, false)
+	checkBudget(t, NewBudget(nil, -1, false, 0), `kitten kitten`, `sitting sitting`,
+		`-13 +15`, true)
+	checkBudget(t, NewBudget(nil, -1, false, 300), `kitten kitten`, `sitting sitting`,
+		`-6 +7 =1 -2 +2 =2 -1 +1 =1 +1`, true)
+	checkBudget(t, NewBudget(nil, -1, true, 0), `A`, `AB`, `=1 +1`, false)
+}
 
-	_, remainder := s.Pop()
-	intEqual(t, remainder, 3, `popped remainder`)
-	intEqual(t, countNodes(s.top), 2, `top count`)
-	intEqual(t, countNodes(s.graveyard), 1, `graveyard count`)
-	boolEqual(t, s.NotEmpty(), true, `not empty`)
+func Test_Hirschberg_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
 
-	_, remainder = s.Pop()
-	intEqual(t, remainder, 2, `popped remainder`)
-	intEqual(t, countNodes(s.top), 1, `top count`)
-	intEqual(t, countNodes(s.graveyard), 2, `graveyard count`)
-	boolEqual(t, s.NotEmpty(), true, `not empty`)
+	d := New(New(nil, 6, false), -1, true)
+	col := collector.New()
+	cont := container.NewContext(ctx, comparable.NewChar(`kitten kitten`, `sitting sitting`), nil)
+	d.Diff(cont, col)
+	col.Finish()
+	boolEqual(t, col.Approximate(), true, `approximate`)
+	if result := col.String(); result != `-13 +15` {
+		t.Error("Hirschberg returned unexpected result for a canceled diff: ", result)
+	}
+}
 
-	_, remainder = s.Pop()
-	intEqual(t, remainder, 1, `popped remainder`)
-	intEqual(t, countNodes(s.top), 0, `top count`)
-	intEqual(t, countNodes(s.graveyard), 3, `graveyard count`)
-	boolEqual(t, s.NotEmpty(), false, `not empty`)
+func Test_Hirschberg_Parallel(t *testing.T) {
+	checkAll(t, NewParallel(nil, -1, false, 4))
+	checkAll(t, NewParallel(nil, -1, true, 4))
 
-	_, remainder = s.Pop()
-	intEqual(t, remainder, 0, `popped remainder`)
-	intEqual(t, countNodes(s.top), 0, `top count`)
-	intEqual(t, countNodes(s.graveyard), 3, `graveyard count`)
-	boolEqual(t, s.NotEmpty(), false, `not empty`)
+	// The inputs must be large enough to be split in parallel.
+	r := rand.New(rand.NewSource(42))
+	randText := func(length int) string {
+		text := make([]byte, length)
+		for i := range text {
+			text[i] = byte('a' + r.Intn(4))
+		}
+		return string(text)
+	}
+	newHybrid := func() container.Diff { return New(nil, 6, false) }
+	for i := 0; i < 4; i++ {
+		a, b := randText(400+r.Intn(200)), randText(400+r.Intn(200))
+		exp := diffString(New(newHybrid(), -1, true), a, b)
+		expNoReduce := diffString(New(nil, -1, false), a, b)
+		for _, parallelism := range []int{0, 1, 2, 8} {
+			check(t, NewParallel(newHybrid, -1, true, parallelism), a, b, exp)
+			check(t, NewParallel(nil, -1, false, parallelism), a, b, expNoReduce)
+		}
+	}
 
-	s.Push(nil, 5)
-	intEqual(t, countNodes(s.top), 1, `top count`)
-	intEqual(t, countNodes(s.graveyard), 2, `graveyard count`)
-	boolEqual(t, s.NotEmpty(), true, `not empty`)
+	// The vectors of all the workers are grown before the workers are given any parts.
+	cont := container.New(comparable.NewChar(randText(500), randText(600)))
+	diff := NewParallel(newHybrid, 100, true, 4)
+	boolEqual(t, diff.NoResizeNeeded(container.New(comparable.NewChar(randText(99), randText(99)))), true, `no resize for small`)
+	boolEqual(t, diff.NoResizeNeeded(cont), false, `no resize before diff`)
+	diff.Diff(cont, collector.New())
+	boolEqual(t, diff.NoResizeNeeded(cont), true, `no resize after diff`)
+	for _, worker := range diff.(*parallel).workers {
+		boolEqual(t, worker.NoResizeNeeded(cont), true, `worker no resize after diff`)
+	}
 }
 
-func countNodes(node *stackNode) int {
-	count := 0
-	for ; node != nil; count++ {
-		node = node.prev
+func Test_Hirschberg_ConcurrentSplit(t *testing.T) {
+	checkAll(t, NewConcurrentSplit(nil, -1, false))
+	checkAll(t, NewConcurrentSplit(nil, -1, true))
+
+	// The inputs must be large enough for the halves of a split to be calculated at the same time.
+	r := rand.New(rand.NewSource(42))
+	randText := func(length int) string {
+		text := make([]byte, length)
+		for i := range text {
+			text[i] = byte('a' + r.Intn(4))
+		}
+		return string(text)
+	}
+	for i := 0; i < 4; i++ {
+		a, b := randText(400+r.Intn(200)), randText(400+r.Intn(200))
+		check(t, NewConcurrentSplit(nil, -1, true), a, b, diffString(New(nil, -1, true), a, b))
+		check(t, NewConcurrentSplit(New(nil, 6, false), -1, false), a, b, diffString(New(New(nil, 6, false), -1, false), a, b))
 	}
-	return count
 }
 
-func checkAll(t *testing.T, d container.Diff) {
-	check(t, d, `A`, `A`, `=1`)
-	check(t, d, `A`, `B`, `-1 +1`)
-	check(t, d, `A`, `AB`, `=1 +1`)
-	check(t, d, `A`, `BA`, `+1 =1`)
-	check(t, d, `AB`, `A`, `=1 -1`)
-	check(t, d, `BA`, `A`, `-1 =1`)
-	check(t, d, `kitten`, `sitting`, `-1 +1 =3 -1 +1 =1 +1`)
-	check(t, d, `saturday`, `sunday`, `=1 -2 =1 -1 +1 =3`)
-	check(t, d, `satxrday`, `sunday`, `=1 -4 +2 =3`)
-	check(t, d, `ABC`, `ADB`, `=1 +1 =1 -1`)
+func Test_Scores_SplitHelper(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	randText := func(length int) string {
+		text := make([]byte, length)
+		for i := range text {
+			text[i] = byte('a' + r.Intn(4))
+		}
+		return string(text)
+	}
+	affine := container.DefaultCosts()
+	affine.GapOpen = 3
+	affine.Substition = 8
+
+	s, helper := newScores(-1), newScores(-1)
+	for i := 0; i < 20; i++ {
+		comp := comparable.NewChar(randText(1+r.Intn(100)), randText(1+r.Intn(100)))
+		for _, costs := range []*container.Costs{nil, affine} {
+			cont := container.NewCosts(comp, costs)
+			aMid, bMid, crossing := s.Split(cont, nil)
+			aMid2, bMid2, crossing2 := s.Split(cont, helper)
+			intEqual(t, aMid2, aMid, `A mid point`)
+			intEqual(t, bMid2, bMid, `B mid point`)
+			boolEqual(t, crossing2, crossing, `crossing`)
+		}
+	}
+}
+
+func Test_Hirschberg_ParallelPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != `malformed` {
+			t.Error("Expected the panic to be passed to the caller: ", r)
+		}
+	}()
+
+	a, b := ``, ``
+	for i := 0; i < 500; i++ {
+		a, b = a+`ab`, b+`ba`
+	}
+	cont := container.New(panickingChar{Char: comparable.NewChar(a, b), aIndex: 900})
+	NewParallel(nil, -1, true, 4).Diff(cont, collector.New())
+	t.Error("Expected the diff to panic.")
+}
+
+// panickingChar is a comparable which panics when the given A index is compared.
+type panickingChar struct {
+	*comparable.Char
+	aIndex int
+}
+
+// Equals panics if the given A index is the index to panic at.
+func (c panickingChar) Equals(aIndex, bIndex int) bool {
+	if aIndex == c.aIndex {
+		panic(`malformed`)
+	}
+	return c.Char.Equals(aIndex, bIndex)
 }
 
 func boolEqual(t *testing.T, value, exp bool, msg string) {
@@ -117,10 +236,30 @@ func intEqual(t *testing.T, value, exp int, msg string) {
 	}
 }
 
+// diffString gets the string for the result of the diff of the given inputs.
+func diffString(d container.Diff, a, b string) string {
+	col := collector.New()
+	cont := container.New(comparable.NewChar(a, b))
+	d.Diff(cont, col)
+	col.Finish()
+	return col.String()
+}
+
 // checks the levenshtein distance algorithm
 func check(t *testing.T, d container.Diff, a, b, exp string) {
+	if result := diffString(d, a, b); exp != result {
+		t.Error("Hirschberg returned unexpected result:",
+			"\n   Input A:  ", a,
+			"\n   Input B:  ", b,
+			"\n   Expected: ", exp,
+			"\n   Result:   ", result)
+	}
+}
+
+// checks the diff algorithm with custom costs
+func checkCosts(t *testing.T, d container.Diff, a, b string, costs *container.Costs, exp string) {
 	col := collector.New()
-	cont := container.New(comparable.NewChar(a, b))
+	cont := container.NewCosts(comparable.NewChar(a, b), costs)
 	d.Diff(cont, col)
 	col.Finish()
 	if result := col.String(); exp != result {
@@ -131,3 +270,18 @@ func check(t *testing.T, d container.Diff, a, b, exp string) {
 			"\n   Result:   ", result)
 	}
 }
+
+// checks the diff algorithm with an operation budget
+func checkBudget(t *testing.T, d container.Diff, a, b, exp string, expApprox bool) {
+	col := collector.New()
+	cont := container.New(comparable.NewChar(a, b))
+	d.Diff(cont, col)
+	col.Finish()
+	if result := col.String(); exp != result || col.Approximate() != expApprox {
+		t.Error("Hirschberg returned unexpected result:",
+			"\n   Input A:  ", a,
+			"\n   Input B:  ", b,
+			"\n   Expected: ", exp, " (approximate: ", expApprox, ")",
+			"\n   Result:   ", result, " (approximate: ", col.Approximate(), ")")
+	}
+}
@@ -0,0 +1,184 @@
+package hirschberg
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/Grant-Nelson/goDiff/internal/collector"
+	"github.com/Grant-Nelson/goDiff/internal/container"
+	"github.com/Grant-Nelson/goDiff/step"
+)
+
+// parallelCutoff is the smallest A length times B length of a container which is split
+// so that its parts can be given to other workers, or whose halves are calculated at the
+// same time. Smaller containers are diffed by a single goroutine since handing off
+// the work would cost more than doing it.
+const parallelCutoff = 1 << 16
+
+// parallel will perform a Hirschberg diff where the parts left after each split are
+// diffed at the same time by a bounded number of workers. Each worker is a Hirschberg
+// with its own score vectors and hybrid so that the workers don't share any memory.
+// A worker splitting a large container may also use an idle worker's score vectors
+// to calculate both halves of the split at the same time.
+type parallel struct {
+	workers   []*hirschberg
+	idle      chan *hirschberg
+	useReduce bool
+
+	// length is the score vector size which all the workers have allocated.
+	// This is only read and written by the goroutine calling the diff,
+	// so that it can be checked while workers are resizing their own vectors.
+	length int
+}
+
+// NewParallel creates a new Hirschberg diff algorithm which diffs in parallel.
+//
+// The given parallelism is the maximum number of workers, each running in its own goroutine,
+// to diff with. Use 0 or less to use runtime.GOMAXPROCS workers. When the parallelism is
+// one the diff is the same as New. The results are the same as New for any parallelism.
+// The comparable being diffed must be safe to read from multiple goroutines.
+//
+// The given newHybrid creates the hybrid for each worker, since a hybrid can't be shared
+// between workers. Pass in nil to not use a hybrid.
+// The length and useReduce are the same as for New.
+func NewParallel(newHybrid func() container.Diff, length int, useReduce bool, parallelism int) container.Diff {
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	hybrid := func() container.Diff {
+		if newHybrid == nil {
+			return nil
+		}
+		return newHybrid()
+	}
+	if parallelism == 1 {
+		return New(hybrid(), length, useReduce)
+	}
+
+	p := &parallel{
+		workers:   make([]*hirschberg, parallelism),
+		idle:      make(chan *hirschberg, parallelism),
+		useReduce: useReduce,
+	}
+	if length > 0 {
+		p.length = length
+	}
+	for i := range p.workers {
+		p.workers[i] = New(hybrid(), length, useReduce).(*hirschberg)
+		p.workers[i].idle = p.idle
+		p.idle <- p.workers[i]
+	}
+	return p
+}
+
+// NoResizeNeeded determines if the diff algorithm can handle a container with
+// the amount of data inside of the given container.
+// The workers' score vectors will be auto-resize if needed so this method
+// only indicates if the current vectors are large enough to not need reallocation.
+func (p *parallel) NoResizeNeeded(cont *container.Container) bool {
+	return p.length >= cont.BLength()+1
+}
+
+// Diff performs the algorithm on the given container
+// and writes the results to the collector.
+func (p *parallel) Diff(cont *container.Container, col *collector.Collector) {
+	if length := cont.BLength() + 1; length > p.length {
+		// None of the workers are running between diffs, so all their vectors can be grown here.
+		for _, worker := range p.workers {
+			worker.scores.allocateVectors(length)
+		}
+		p.length = length
+	}
+
+	w := <-p.idle
+	defer func() { p.idle <- w }()
+	p.diff(cont, col, w)
+}
+
+// diff performs the algorithm on the given container with the given worker
+// and writes the results to the collector. Any parts left after a split are
+// handed off to the idle workers, the rest are diffed with the given worker.
+func (p *parallel) diff(cont *container.Container, col *collector.Collector, w *hirschberg) {
+	if cont.Canceled() {
+		cont.Coarse(col)
+		return
+	}
+
+	var before, after int
+	if p.useReduce {
+		cont, before, after = cont.Reduce()
+	}
+	col.InsertEqual(after)
+
+	if cont.ALength()*cont.BLength() < parallelCutoff {
+		w.Diff(cont, col)
+	} else {
+		remaining := w.budget
+		p.diffParts(w.divide(cont, col, &remaining), col, w)
+	}
+	col.InsertEqual(before)
+}
+
+// diffParts diffs the given parts of a split container and writes the results to the collector.
+// The parts are diffed into separate segments which are then joined in order into the collector.
+func (p *parallel) diffParts(parts []*container.Container, col *collector.Collector, w *hirschberg) {
+	if len(parts) == 0 {
+		return
+	}
+
+	// The last part is written first since the collector expects reverse order.
+	last := len(parts) - 1
+	segments := make([]*collector.Collector, last)
+	failures := make([]interface{}, last)
+	func() {
+		// Always wait for the other workers, even if a part diffed here panics,
+		// so that none of the workers are still running after the diff returns.
+		wg := sync.WaitGroup{}
+		defer wg.Wait()
+		for i := 0; i < last; i++ {
+			segments[i] = collector.New()
+			select {
+			case other := <-p.idle:
+				wg.Add(1)
+				go func(i int) {
+					defer func() {
+						failures[i] = recover()
+						p.idle <- other
+						wg.Done()
+					}()
+					p.diff(parts[i], segments[i], other)
+				}(i)
+			default:
+				p.diff(parts[i], segments[i], w)
+			}
+		}
+		p.diff(parts[last], col, w)
+	}()
+
+	for i := last - 1; i >= 0; i-- {
+		if failures[i] != nil {
+			// Pass a panic, such as from a malformed comparable, to the caller's goroutine.
+			panic(failures[i])
+		}
+		insertSegment(col, segments[i])
+	}
+}
+
+// insertSegment inserts all the steps from the given segment into the given collector.
+func insertSegment(col *collector.Collector, segment *collector.Collector) {
+	segment.Finish()
+	types := make([]step.Type, 0, segment.Count())
+	counts := make([]int, 0, segment.Count())
+	segment.Read(func(stepType step.Type, count int) {
+		types = append(types, stepType)
+		counts = append(counts, count)
+	})
+
+	// The collector expects the steps in reverse order.
+	for i := len(types) - 1; i >= 0; i-- {
+		col.InsertStep(types[i], counts[i])
+	}
+	if segment.Approximate() {
+		col.InsertCoarse(0, 0)
+	}
+}
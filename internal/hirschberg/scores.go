@@ -15,6 +15,18 @@ type scores struct {
 
 	// other is the score vector to store off a result vector to.
 	other []int
+
+	// gapFront is the affine score vector for paths ending in a remove
+	// at the front of the score calculation.
+	gapFront []int
+
+	// gapBack is the affine score vector for paths ending in a remove
+	// at the back of the score calculation.
+	gapBack []int
+
+	// gapOther is the affine score vector for paths ending in a remove
+	// to store off a result vector to.
+	gapOther []int
 }
 
 // newScores creates a new path builder. The given length must be one greater
@@ -46,6 +58,7 @@ func (s *scores) store() {
 
 // calculate calculates the Needleman-Wunsch score.
 // At the end of this calculation the score is in the back vector.
+// If the container is canceled the calculation is stopped early.
 func (s *scores) calculate(cont *container.Container) {
 	aLen := cont.ALength()
 	bLen := cont.BLength()
@@ -55,29 +68,34 @@ func (s *scores) calculate(cont *container.Container) {
 
 	s.back[0] = 0
 	for j := 1; j <= bLen; j++ {
-		s.back[j] = s.back[j-1] + container.AddCost
+		s.back[j] = s.back[j-1] + cont.AddCost(j-1)
 	}
 
 	for i := 1; i <= aLen; i++ {
-		s.front[0] = s.back[0] + container.RemoveCost
+		if cont.Canceled() {
+			return
+		}
+
+		removeCost := cont.RemoveCost(i - 1)
+		s.front[0] = s.back[0] + removeCost
 		for j := 1; j <= bLen; j++ {
 			s.front[j] = container.Min3(
 				s.back[j-1]+cont.SubstitionCost(i-1, j-1),
-				s.back[j]+container.RemoveCost,
-				s.front[j-1]+container.AddCost)
+				s.back[j]+removeCost,
+				s.front[j-1]+cont.AddCost(j-1))
 		}
 
 		s.swap()
 	}
 }
 
-// findPivot finds the pivot between the other score and the reverse of the back score.
+// findPivot finds the pivot between the given forward scores and the reverse of the back score.
 // The pivot is the index of the maximum sum of each element in the two scores.
-func (s *scores) findPivot(bLength int) int {
+func (s *scores) findPivot(forward []int, bLength int) int {
 	index := 0
-	min := s.other[0] + s.back[bLength]
+	min := forward[0] + s.back[bLength]
 	for j := 1; j <= bLength; j++ {
-		value := s.other[j] + s.back[bLength-j]
+		value := forward[j] + s.back[bLength-j]
 		if value < min {
 			min = value
 			index = j
@@ -86,16 +104,62 @@ func (s *scores) findPivot(bLength int) int {
 	return index
 }
 
+// calculateHalves calculates the scores for the given front half and the scores for the given
+// reversed back half. The back half's scores are left in the back vectors and the front half's
+// scores are returned. If a helper is given the front half is calculated with the helper's
+// vectors at the same time as the back half, otherwise the halves are calculated one after the other.
+func (s *scores) calculateHalves(front, back *container.Container, helper *scores, affine bool) ([]int, []int) {
+	calculate := (*scores).calculate
+	if affine {
+		calculate = (*scores).calculateAffine
+	}
+
+	if helper == nil {
+		calculate(s, front)
+		s.store()
+		s.gapBack, s.gapOther = s.gapOther, s.gapBack
+		calculate(s, back)
+		return s.other, s.gapOther
+	}
+
+	failure := make(chan interface{}, 1)
+	go func() {
+		defer func() { failure <- recover() }()
+		calculate(helper, front)
+	}()
+	func() {
+		// Always wait for the helper so its vectors aren't used after this returns,
+		// and pass any panic from the helper to this goroutine.
+		defer func() {
+			if r := <-failure; r != nil {
+				panic(r)
+			}
+		}()
+		calculate(s, back)
+	}()
+	return helper.back, helper.gapBack
+}
+
 // Split will find the A and B mid points to split the container at.
-func (s *scores) Split(cont *container.Container) (int, int) {
+// Returns true if a run of removes crosses the split, this only
+// happens when the costs are affine.
+//
+// If the given helper isn't nil, its vectors are used to calculate the scores
+// for the front half of the container at the same time as the back half.
+func (s *scores) Split(cont *container.Container, helper *scores) (int, int, bool) {
+	if cont.Affine() {
+		return s.affineSplit(cont, helper)
+	}
+
 	aLen := cont.ALength()
 	bLen := cont.BLength()
 
 	aMid := aLen / 2
-	s.calculate(cont.Sub(0, aMid, 0, bLen, false))
-	s.store()
-	s.calculate(cont.Sub(aMid, aLen, 0, bLen, true))
-	bMid := s.findPivot(bLen)
+	forward, _ := s.calculateHalves(
+		cont.Sub(0, aMid, 0, bLen, false),
+		cont.Sub(aMid, aLen, 0, bLen, true),
+		helper, false)
+	bMid := s.findPivot(forward, bLen)
 
-	return aMid, bMid
+	return aMid, bMid, false
 }
@@ -0,0 +1,86 @@
+package histogram
+
+import (
+	"github.com/Grant-Nelson/goDiff/internal/collector"
+	"github.com/Grant-Nelson/goDiff/internal/container"
+)
+
+// maxChainLength is the maximum number of occurrences a part may have
+// and still be used as an anchor. This is the same limit used by git.
+const maxChainLength = 64
+
+// histogram will perform a histogram diff on the given comparable.
+// The algorithm is the histogram diff from JGit and git (`git diff --histogram`).
+// It counts how often each part of A occurs, then finds the longest common run
+// around the parts in B which occur the least often in A. That run is used to
+// divide the problem space. When only parts which occur too often are common
+// the fallback is used.
+type histogram struct {
+	index    *index
+	fallback container.Diff
+}
+
+// New creates a new histogram diff algorithm.
+//
+// The given fallback is used for any part of the comparable where the common parts
+// all occur too often to be used as anchors. The comparable must be hashable to count
+// the occurrences, if it isn't, the fallback will be used for the whole comparable.
+func New(fallback container.Diff) container.Diff {
+	return &histogram{
+		index:    newIndex(),
+		fallback: fallback,
+	}
+}
+
+// NoResizeNeeded determines if the diff algorithm can handle a container with
+// the amount of data inside of the given container.
+// This algorithm's index will be auto-resize if needed so this method
+// only indicates if the current index is large enough to not need reallocation.
+func (h *histogram) NoResizeNeeded(cont *container.Container) bool {
+	return len(h.index.next) >= cont.ALength()
+}
+
+// Diff performs the algorithm on the given container
+// and writes the results to the collector.
+func (h *histogram) Diff(cont *container.Container, col *collector.Collector) {
+	if !cont.Hashable() {
+		h.fallback.Diff(cont, col)
+		return
+	}
+
+	stack := container.NewStack()
+	stack.Push(cont, 0)
+
+	for stack.NotEmpty() {
+		cur, remainder := stack.Pop()
+		col.InsertEqual(remainder)
+		if cur == nil {
+			continue
+		}
+
+		if cur.Canceled() {
+			cur.Coarse(col)
+			continue
+		}
+
+		if cur.EndCase(col) {
+			continue
+		}
+
+		lcs, useFallback := h.index.findLCS(cur)
+		if useFallback {
+			h.fallback.Diff(cur, col)
+			continue
+		}
+
+		aLen, bLen := cur.ALength(), cur.BLength()
+		if !lcs.found {
+			col.InsertAdded(bLen)
+			col.InsertRemoved(aLen)
+			continue
+		}
+
+		stack.Push(cur.Sub(0, lcs.aBegin, 0, lcs.bBegin, false), lcs.aEnd-lcs.aBegin+1)
+		stack.Push(cur.Sub(lcs.aEnd+1, aLen, lcs.bEnd+1, bLen, false), 0)
+	}
+}
@@ -0,0 +1,128 @@
+package histogram
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Grant-Nelson/goDiff/comparable"
+	"github.com/Grant-Nelson/goDiff/internal/collector"
+	"github.com/Grant-Nelson/goDiff/internal/container"
+	"github.com/Grant-Nelson/goDiff/internal/wagner"
+)
+
+func Test_Histogram(t *testing.T) {
+	d := New(wagner.New(-1))
+	check(t, d, `A`, `A`, `=1`)
+	check(t, d, `A`, `B`, `-1 +1`)
+	check(t, d, `A`, `AB`, `=1 +1`)
+	check(t, d, `A`, `BA`, `+1 =1`)
+	check(t, d, `AB`, `A`, `=1 -1`)
+	check(t, d, `BA`, `A`, `-1 =1`)
+	check(t, d, `kitten`, `sitting`, `-1 +1 =3 -1 +1 =1 +1`)
+	check(t, d, `saturday`, `sunday`, `=1 -2 =1 -1 +1 =3`)
+	check(t, d, `satxrday`, `sunday`, `=1 -4 +2 =3`)
+	check(t, d, `ABC`, `ADB`, `=1 +1 =1 -1`)
+	check(t, d, `ABC`, `XYZ`, `-3 +3`)
+}
+
+func Test_Histogram_LowOccurrence(t *testing.T) {
+	d := New(wagner.New(-1))
+
+	// The unique "j" is used as the anchor instead of the more common "n".
+	checkLines(t, d,
+		`g a h a j m d l b b k a d g h e f n h d a m { l m a e n`,
+		`h i m d d n i g j c i n`,
+		`-2 =1 -1 +7 =1 -12 +2 =1 -10`)
+
+	// The longest run is used when the occurrences are the same.
+	checkLines(t, d,
+		`a b c x a b d`,
+		`a b d y a b c`,
+		`-4 =3 +4`)
+}
+
+func Test_Histogram_Fallback(t *testing.T) {
+	d := New(&recordDiff{diff: wagner.New(-1)})
+	a := strings.Repeat(`a`, maxChainLength) + `x`
+	b := `y` + strings.Repeat(`a`, maxChainLength)
+	check(t, d, a, b, fmt.Sprintf(`+1 =%d -1`, maxChainLength))
+	intEqual(t, d.(*histogram).fallback.(*recordDiff).calls, 0, `fallback calls`)
+
+	// Too many occurrences to be used as an anchor.
+	a = strings.Repeat(`a`, maxChainLength+1) + `x`
+	b = `y` + strings.Repeat(`a`, maxChainLength+1)
+	check(t, d, a, b, fmt.Sprintf(`+1 =%d -1`, maxChainLength+1))
+	intEqual(t, d.(*histogram).fallback.(*recordDiff).calls, 1, `fallback calls`)
+}
+
+func Test_Histogram_NotHashable(t *testing.T) {
+	d := New(wagner.New(-1))
+	comp := comparable.NewInterface(
+		[]interface{}{1, 2, 3},
+		[]interface{}{1, 4, 3}, nil)
+	col := collector.New()
+	d.Diff(container.New(comp), col)
+	col.Finish()
+	if result := col.String(); result != `=1 -1 +1 =1` {
+		t.Error("Histogram returned unexpected result:",
+			"\n   Expected: ", `=1 -1 +1 =1`,
+			"\n   Result:   ", result)
+	}
+}
+
+// recordDiff is a diff which records how many times it was called.
+type recordDiff struct {
+	diff  container.Diff
+	calls int
+}
+
+func (r *recordDiff) NoResizeNeeded(cont *container.Container) bool {
+	return r.diff.NoResizeNeeded(cont)
+}
+
+func (r *recordDiff) Diff(cont *container.Container, col *collector.Collector) {
+	r.calls++
+	r.diff.Diff(cont, col)
+}
+
+func intEqual(t *testing.T, value, exp int, msg string) {
+	if value != exp {
+		t.Error(fmt.Sprint("Unexpected integer value:",
+			"\n   Message:  ", msg,
+			"\n   Value:    ", value,
+			"\n   Expected: ", exp))
+	}
+}
+
+// checks the histogram algorithm
+func check(t *testing.T, d container.Diff, a, b, exp string) {
+	col := collector.New()
+	cont := container.New(comparable.NewChar(a, b))
+	if !cont.EndCase(col) {
+		d.Diff(cont, col)
+	}
+	col.Finish()
+	if result := col.String(); exp != result {
+		t.Error("Histogram returned unexpected result:",
+			"\n   Input A:  ", a,
+			"\n   Input B:  ", b,
+			"\n   Expected: ", exp,
+			"\n   Result:   ", result)
+	}
+}
+
+// checks the histogram algorithm on space separated lines
+func checkLines(t *testing.T, d container.Diff, a, b, exp string) {
+	col := collector.New()
+	cont := container.New(comparable.NewString(strings.Split(a, ` `), strings.Split(b, ` `)))
+	d.Diff(cont, col)
+	col.Finish()
+	if result := col.String(); exp != result {
+		t.Error("Histogram returned unexpected result:",
+			"\n   Input A:  ", a,
+			"\n   Input B:  ", b,
+			"\n   Expected: ", exp,
+			"\n   Result:   ", result)
+	}
+}
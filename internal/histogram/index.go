@@ -0,0 +1,168 @@
+package histogram
+
+import "github.com/Grant-Nelson/goDiff/internal/container"
+
+type (
+	// record is the occurrences of parts in A which have the same hash.
+	record struct {
+
+		// first is the index of the first occurrence in A.
+		first int
+
+		// count is the number of occurrences in A.
+		count int
+	}
+
+	// region is the longest common run found between A and B.
+	region struct {
+
+		// found indicates if any common run was found.
+		found bool
+
+		// aBegin is the inclusive index of the start of the run in A.
+		aBegin int
+
+		// aEnd is the inclusive index of the end of the run in A.
+		aEnd int
+
+		// bBegin is the inclusive index of the start of the run in B.
+		bBegin int
+
+		// bEnd is the inclusive index of the end of the run in B.
+		bEnd int
+	}
+
+	// index is the histogram of the occurrences of the parts of A
+	// used to find the longest common run with the lowest occurrence.
+	index struct {
+
+		// records is the occurrences for each hash of the parts in A.
+		records map[uint64]*record
+
+		// next is the index of the next occurrence in A
+		// for each part in A or -1 if there are no more.
+		next []int
+
+		// lineMap is the record for each part in A.
+		lineMap []*record
+
+		// count is the lowest occurrence count of the current longest common run.
+		count int
+
+		// hasCommon indicates if any common part has been found.
+		hasCommon bool
+	}
+)
+
+// newIndex creates a new histogram index.
+func newIndex() *index {
+	return &index{}
+}
+
+// scanA builds the histogram of the parts of A in the given container.
+// The occurrences are chained in order of their index.
+func (idx *index) scanA(cont *container.Container) {
+	aLen := cont.ALength()
+	if len(idx.next) < aLen {
+		idx.next = make([]int, aLen)
+		idx.lineMap = make([]*record, aLen)
+	}
+
+	idx.records = make(map[uint64]*record, aLen)
+	for i := aLen - 1; i >= 0; i-- {
+		hash := cont.AHash(i)
+		rec, ok := idx.records[hash]
+		if ok {
+			idx.next[i] = rec.first
+			rec.first = i
+			rec.count++
+		} else {
+			idx.next[i] = -1
+			rec = &record{
+				first: i,
+				count: 1,
+			}
+			idx.records[hash] = rec
+		}
+		idx.lineMap[i] = rec
+	}
+}
+
+// findLCS finds the longest common run around the parts with the lowest occurrence.
+// Returns true if the fallback should be used because every common part
+// occurs more than the maximum chain length.
+func (idx *index) findLCS(cont *container.Container) (region, bool) {
+	idx.scanA(cont)
+	idx.count = maxChainLength + 1
+	idx.hasCommon = false
+
+	lcs := region{}
+	for bLen, j := cont.BLength(), 0; j < bLen; {
+		j = idx.tryLCS(cont, &lcs, j)
+	}
+	return lcs, idx.hasCommon && maxChainLength < idx.count
+}
+
+// tryLCS checks the runs around each occurrence in A of the part at the given B index.
+// The given longest common run is updated if a longer run, or one with a lower occurrence,
+// is found. The next B index to check is returned.
+func (idx *index) tryLCS(cont *container.Container, lcs *region, bPtr int) int {
+	bNext := bPtr + 1
+	rec, ok := idx.records[cont.BHash(bPtr)]
+	if !ok {
+		return bNext
+	}
+
+	if rec.count > idx.count {
+		if !idx.hasCommon {
+			idx.hasCommon = cont.Equals(rec.first, bPtr)
+		}
+		return bNext
+	}
+
+	aLen, bLen := cont.ALength(), cont.BLength()
+	for as := rec.first; as >= 0; {
+		if !cont.Equals(as, bPtr) {
+			// The hashes collided, skip this occurrence.
+			as = idx.next[as]
+			continue
+		}
+		idx.hasCommon = true
+
+		np := idx.next[as]
+		bs, ae, be, rc := bPtr, as, bPtr, rec.count
+		for as > 0 && bs > 0 && cont.Equals(as-1, bs-1) {
+			as, bs = as-1, bs-1
+			if rc > 1 {
+				rc = container.Min2(rc, idx.lineMap[as].count)
+			}
+		}
+		for ae < aLen-1 && be < bLen-1 && cont.Equals(ae+1, be+1) {
+			ae, be = ae+1, be+1
+			if rc > 1 {
+				rc = container.Min2(rc, idx.lineMap[ae].count)
+			}
+		}
+
+		if bNext <= be {
+			bNext = be + 1
+		}
+		if lcs.aEnd-lcs.aBegin < ae-as || rc < idx.count {
+			*lcs = region{
+				found:  true,
+				aBegin: as,
+				aEnd:   ae,
+				bBegin: bs,
+				bEnd:   be,
+			}
+			idx.count = rc
+		}
+
+		// Skip any occurrences which were part of the run just checked.
+		for np >= 0 && np <= ae {
+			np = idx.next[np]
+		}
+		as = np
+	}
+	return bNext
+}
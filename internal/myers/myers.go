@@ -0,0 +1,170 @@
+package myers
+
+import (
+	"github.com/Grant-Nelson/goDiff/internal/collector"
+	"github.com/Grant-Nelson/goDiff/internal/container"
+)
+
+// myers will perform a Myers' O(ND) diff on the given comparable.
+// The algorithm is Myers' algorithm (http://www.xmailserver.org/diff2.pdf)
+// using the linear space refinement which finds the middle snake of the
+// edit graph to divide the problem space.
+type myers struct {
+
+	// forward is the furthest reaching x values for the forward search
+	// indexed by the diagonal plus an offset.
+	forward []int
+
+	// backward is the furthest reaching x values for the backward search
+	// indexed by the diagonal plus an offset.
+	backward []int
+}
+
+// New creates a new Myers diff algorithm.
+//
+// The given length is the initial size of the furthest reaching vectors.
+// If the vectors are too small they will be reallocated to the larger size.
+// The vectors must be four larger than the sum of the A and B lengths
+// being diffed. Use -1 to not preallocate the vectors.
+func New(length int) container.Diff {
+	m := &myers{}
+	if length > 0 {
+		m.allocateVectors(length)
+	}
+	return m
+}
+
+// allocateVectors will create the slices used for the furthest reaching vectors.
+func (m *myers) allocateVectors(length int) {
+	m.forward = make([]int, length)
+	m.backward = make([]int, length)
+}
+
+// vectorLength gets the length of the vectors needed for the given container.
+func vectorLength(cont *container.Container) int {
+	return cont.ALength() + cont.BLength() + 4
+}
+
+// NoResizeNeeded determines if the diff algorithm can handle a container with
+// the amount of data inside of the given container.
+// This algorithm's vectors will be auto-resize if needed so this method
+// only indicates if the current vectors are large enough to not need reallocation.
+func (m *myers) NoResizeNeeded(cont *container.Container) bool {
+	return len(m.forward) >= vectorLength(cont)
+}
+
+// Diff performs the algorithm on the given container
+// and writes the results to the collector.
+func (m *myers) Diff(cont *container.Container, col *collector.Collector) {
+	stack := container.NewStack()
+	stack.Push(cont, 0)
+
+	for stack.NotEmpty() {
+		cur, remainder := stack.Pop()
+		col.InsertEqual(remainder)
+		if cur == nil {
+			continue
+		}
+
+		if cur.Canceled() {
+			cur.Coarse(col)
+			continue
+		}
+
+		var before, after int
+		cur, before, after = cur.Reduce()
+		col.InsertEqual(after)
+		stack.Push(nil, before)
+
+		if cur.EndCase(col) {
+			continue
+		}
+
+		aLen, bLen := cur.ALength(), cur.BLength()
+		x, y, u, v := m.middleSnake(cur)
+		if cur.Canceled() {
+			cur.Coarse(col)
+			continue
+		}
+
+		stack.Push(cur.Sub(0, x, 0, y, false), u-x)
+		stack.Push(cur.Sub(u, aLen, v, bLen, false), 0)
+	}
+}
+
+// middleSnake finds the middle snake of the shortest edit path through the given container.
+// The start, (x, y), and the end, (u, v), of the snake are returned. The snake is the
+// run of equal parts in the middle of the path, which may be empty.
+//
+// The given container is expected to have been reduced so that the
+// shortest edit path has at least two edits.
+func (m *myers) middleSnake(cont *container.Container) (int, int, int, int) {
+	aLen, bLen := cont.ALength(), cont.BLength()
+	if size := vectorLength(cont); len(m.forward) < size {
+		m.allocateVectors(size)
+	}
+
+	rev := cont.Sub(0, aLen, 0, bLen, true)
+	delta := aLen - bLen
+	odd := delta&1 != 0
+	offset := (aLen+bLen+1)/2 + 1
+	m.forward[offset+1] = 0
+	m.backward[offset+1] = 0
+
+	for d := 0; d <= (aLen+bLen+1)/2; d++ {
+		if cont.Canceled() {
+			break
+		}
+
+		// Search forward from the top left of the edit graph.
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && m.forward[offset+k-1] < m.forward[offset+k+1]) {
+				x = m.forward[offset+k+1]
+			} else {
+				x = m.forward[offset+k-1] + 1
+			}
+			y := x - k
+			x0, y0 := x, y
+			for x < aLen && y < bLen && cont.Equals(x, y) {
+				x, y = x+1, y+1
+			}
+			m.forward[offset+k] = x
+
+			if kr := delta - k; odd && kr >= -(d-1) && kr <= d-1 {
+				if x+m.backward[offset+kr] >= aLen {
+					return x0, y0, x, y
+				}
+			}
+		}
+
+		// Search backward from the bottom right of the edit graph.
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && m.backward[offset+k-1] < m.backward[offset+k+1]) {
+				x = m.backward[offset+k+1]
+			} else {
+				x = m.backward[offset+k-1] + 1
+			}
+			y := x - k
+			x0, y0 := x, y
+			for x < aLen && y < bLen && rev.Equals(x, y) {
+				x, y = x+1, y+1
+			}
+			m.backward[offset+k] = x
+
+			if kf := delta - k; !odd && kf >= -d && kf <= d {
+				if x+m.forward[offset+kf] >= aLen {
+					return aLen - x, bLen - y, aLen - x0, bLen - y0
+				}
+			}
+		}
+	}
+
+	// This should be unreachable since the forward and backward searches must
+	// overlap by the time they have each gone half way. If the comparable is
+	// inconsistent, split in the middle so that the diff still finishes.
+	// This is also reached if the container was canceled.
+	aMid, bMid := aLen/2, bLen/2
+	return aMid, bMid, aMid, bMid
+}
@@ -0,0 +1,70 @@
+package myers
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Grant-Nelson/goDiff/comparable"
+	"github.com/Grant-Nelson/goDiff/internal/collector"
+	"github.com/Grant-Nelson/goDiff/internal/container"
+)
+
+func Test_Myers(t *testing.T) {
+	d := New(-1)
+	check(t, d, `A`, `A`, `=1`)
+	check(t, d, `A`, `B`, `-1 +1`)
+	check(t, d, `A`, `AB`, `=1 +1`)
+	check(t, d, `A`, `BA`, `+1 =1`)
+	check(t, d, `AB`, `A`, `=1 -1`)
+	check(t, d, `BA`, `A`, `-1 =1`)
+	check(t, d, `kitten`, `sitting`, `-1 +1 =3 -1 +1 =1 +1`)
+	check(t, d, `saturday`, `sunday`, `=1 -2 =1 -1 +1 =3`)
+	check(t, d, `satxrday`, `sunday`, `=1 -4 +2 =3`)
+	check(t, d, `ABC`, `ADB`, `=1 +1 =1 -1`)
+	check(t, d, `ABCABBA`, `CBABAC`, `-1 +1 =1 -1 =2 -1 =1 +1`)
+}
+
+func Test_Myers_Large(t *testing.T) {
+	a := strings.Repeat(`abcdefghij`, 50)
+	b := a[:120] + `XY` + a[125:300] + a[310:] + `Z`
+	check(t, New(-1), a, b, `=120 -15 +2 =365 +1`)
+}
+
+func Test_NoResizeNeeded(t *testing.T) {
+	d := New(14)
+	boolEqual(t, noResizeNeeded(d, 5, 5), true, `5 + 5`)
+	boolEqual(t, noResizeNeeded(d, 0, 10), true, `0 + 10`)
+	boolEqual(t, noResizeNeeded(d, 3, 7), true, `3 + 7`)
+	boolEqual(t, noResizeNeeded(d, 5, 6), false, `5 + 6`)
+	boolEqual(t, noResizeNeeded(d, 11, 0), false, `11 + 0`)
+}
+
+func noResizeNeeded(d container.Diff, a, b int) bool {
+	comp := comparable.NewChar(strings.Repeat(`x`, a), strings.Repeat(`y`, b))
+	return d.NoResizeNeeded(container.New(comp))
+}
+
+func boolEqual(t *testing.T, value, exp bool, msg string) {
+	if value != exp {
+		t.Error(fmt.Sprint("Unexpected boolean value:",
+			"\n   Message:  ", msg,
+			"\n   Value:    ", value,
+			"\n   Expected: ", exp))
+	}
+}
+
+// checks the levenshtein distance algorithm
+func check(t *testing.T, d container.Diff, a, b, exp string) {
+	col := collector.New()
+	cont := container.New(comparable.NewChar(a, b))
+	d.Diff(cont, col)
+	col.Finish()
+	if result := col.String(); exp != result {
+		t.Error("Myers returned unexpected result:",
+			"\n   Input A:  ", a,
+			"\n   Input B:  ", b,
+			"\n   Expected: ", exp,
+			"\n   Result:   ", result)
+	}
+}
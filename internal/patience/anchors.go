@@ -0,0 +1,103 @@
+package patience
+
+import "github.com/Grant-Nelson/goDiff/internal/container"
+
+type (
+	// match is a pair of equal parts in A and B.
+	match struct {
+
+		// aIndex is the index of the part in A.
+		aIndex int
+
+		// bIndex is the index of the part in B.
+		bIndex int
+	}
+
+	// occurrence is the number of times parts with the same hash
+	// occur in A and B and the index of the last occurrence of each.
+	occurrence struct {
+		aCount int
+		aIndex int
+		bCount int
+		bIndex int
+	}
+)
+
+// uniqueMatches finds all the parts which occur exactly once in both A and B
+// and are equal. The matches are returned in order of their A index.
+func uniqueMatches(cont *container.Container) []match {
+	aLen, bLen := cont.ALength(), cont.BLength()
+	hashes := make([]uint64, aLen)
+	occurrences := make(map[uint64]*occurrence, aLen)
+	for i := 0; i < aLen; i++ {
+		hash := cont.AHash(i)
+		hashes[i] = hash
+		occ, ok := occurrences[hash]
+		if !ok {
+			occ = &occurrence{}
+			occurrences[hash] = occ
+		}
+		occ.aCount++
+		occ.aIndex = i
+	}
+
+	for j := 0; j < bLen; j++ {
+		if occ, ok := occurrences[cont.BHash(j)]; ok {
+			occ.bCount++
+			occ.bIndex = j
+		}
+	}
+
+	matches := []match{}
+	for i, hash := range hashes {
+		occ := occurrences[hash]
+		if occ.aCount == 1 && occ.bCount == 1 && cont.Equals(i, occ.bIndex) {
+			matches = append(matches, match{aIndex: i, bIndex: occ.bIndex})
+		}
+	}
+	return matches
+}
+
+// longestIncreasing finds the longest subsequence of the given matches, which are
+// ordered by A index, where the B index is also increasing. This is found
+// using patience sorting, hence the name of the algorithm.
+func longestIncreasing(matches []match) []match {
+	if len(matches) <= 0 {
+		return nil
+	}
+
+	// tops is the index of the match on top of each pile.
+	// prev is the index of the match on top of the pile to the left
+	// when each match was placed, or -1 for the first pile.
+	tops := []int{}
+	prev := make([]int, len(matches))
+	for index, m := range matches {
+		low, high := 0, len(tops)
+		for low < high {
+			mid := (low + high) / 2
+			if matches[tops[mid]].bIndex < m.bIndex {
+				low = mid + 1
+			} else {
+				high = mid
+			}
+		}
+
+		if low > 0 {
+			prev[index] = tops[low-1]
+		} else {
+			prev[index] = -1
+		}
+
+		if low < len(tops) {
+			tops[low] = index
+		} else {
+			tops = append(tops, index)
+		}
+	}
+
+	result := make([]match, len(tops))
+	for i, index := len(tops)-1, tops[len(tops)-1]; i >= 0; i, index = i-1, prev[index] {
+		result[i] = matches[index]
+	}
+	return result
+}
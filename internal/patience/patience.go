@@ -0,0 +1,81 @@
+package patience
+
+import (
+	"github.com/Grant-Nelson/goDiff/internal/collector"
+	"github.com/Grant-Nelson/goDiff/internal/container"
+)
+
+// patience will perform a patience diff on the given comparable.
+// The algorithm anchors on the parts which are unique in both A and B,
+// keeping the longest increasing run of those anchors, then recurses into the gaps
+// between the anchors. When there are no unique anchors the fallback is used.
+type patience struct {
+	fallback container.Diff
+}
+
+// New creates a new patience diff algorithm.
+//
+// The given fallback is used for any part of the comparable which has no unique anchors.
+// The comparable must be hashable to find anchors, if it isn't, the fallback
+// will be used for the whole comparable.
+func New(fallback container.Diff) container.Diff {
+	return &patience{
+		fallback: fallback,
+	}
+}
+
+// NoResizeNeeded determines if the diff algorithm can handle a container with
+// the amount of data inside of the given container.
+// This algorithm doesn't preallocate anything so it always returns true.
+func (p *patience) NoResizeNeeded(cont *container.Container) bool {
+	return true
+}
+
+// Diff performs the algorithm on the given container
+// and writes the results to the collector.
+func (p *patience) Diff(cont *container.Container, col *collector.Collector) {
+	if !cont.Hashable() {
+		p.fallback.Diff(cont, col)
+		return
+	}
+
+	stack := container.NewStack()
+	stack.Push(cont, 0)
+
+	for stack.NotEmpty() {
+		cur, remainder := stack.Pop()
+		col.InsertEqual(remainder)
+		if cur == nil {
+			continue
+		}
+
+		if cur.Canceled() {
+			cur.Coarse(col)
+			continue
+		}
+
+		var before, after int
+		cur, before, after = cur.Reduce()
+		col.InsertEqual(after)
+		stack.Push(nil, before)
+
+		if cur.EndCase(col) {
+			continue
+		}
+
+		anchors := longestIncreasing(uniqueMatches(cur))
+		if len(anchors) <= 0 {
+			p.fallback.Diff(cur, col)
+			continue
+		}
+
+		// Push the gaps so that the last gap is popped first since
+		// the collector is expecting the results in reverse order.
+		aLow, bLow := 0, 0
+		for _, anchor := range anchors {
+			stack.Push(cur.Sub(aLow, anchor.aIndex, bLow, anchor.bIndex, false), 1)
+			aLow, bLow = anchor.aIndex+1, anchor.bIndex+1
+		}
+		stack.Push(cur.Sub(aLow, cur.ALength(), bLow, cur.BLength(), false), 0)
+	}
+}
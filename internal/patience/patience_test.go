@@ -0,0 +1,135 @@
+package patience
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Grant-Nelson/goDiff/comparable"
+	"github.com/Grant-Nelson/goDiff/internal/collector"
+	"github.com/Grant-Nelson/goDiff/internal/container"
+	"github.com/Grant-Nelson/goDiff/internal/wagner"
+)
+
+func Test_Patience(t *testing.T) {
+	d := New(wagner.New(-1))
+	check(t, d, `A`, `A`, `=1`)
+	check(t, d, `A`, `B`, `-1 +1`)
+	check(t, d, `A`, `AB`, `=1 +1`)
+	check(t, d, `A`, `BA`, `+1 =1`)
+	check(t, d, `AB`, `A`, `=1 -1`)
+	check(t, d, `BA`, `A`, `-1 =1`)
+	check(t, d, `kitten`, `sitting`, `-1 +1 =3 -1 +1 =1 +1`)
+	check(t, d, `saturday`, `sunday`, `=1 -2 =1 -1 +1 =3`)
+	check(t, d, `satxrday`, `sunday`, `=1 -4 +2 =3`)
+	check(t, d, `ABC`, `ADB`, `=1 +1 =1 -1`)
+}
+
+func Test_Patience_Lines(t *testing.T) {
+	d := New(wagner.New(-1))
+	a := []string{
+		`func A() {`,
+		`  a := 1`,
+		`}`,
+		``,
+		`func B() {`,
+		`  b := 2`,
+		`}`}
+	b := []string{
+		`func A() {`,
+		`  a := 1`,
+		`}`,
+		``,
+		`func C() {`,
+		`  c := 3`,
+		`}`,
+		``,
+		`func B() {`,
+		`  b := 2`,
+		`}`}
+	checkLines(t, d, a, b, `=4 +4 =3`)
+
+	// Move B before A, the unique anchors keep B's body together.
+	b = []string{
+		`func B() {`,
+		`  b := 2`,
+		`}`,
+		``,
+		`func A() {`,
+		`  a := 1`,
+		`}`}
+	checkLines(t, d, a, b, `-4 =2 +4 =1`)
+}
+
+func Test_Patience_NotHashable(t *testing.T) {
+	d := New(wagner.New(-1))
+	comp := comparable.NewInterface(
+		[]interface{}{1, 2, 3},
+		[]interface{}{1, 4, 3}, nil)
+	col := collector.New()
+	d.Diff(container.New(comp), col)
+	col.Finish()
+	strEqual(t, col.String(), `=1 -1 +1 =1`, `not hashable`)
+}
+
+func Test_UniqueMatches(t *testing.T) {
+	cont := container.New(comparable.NewChar(`abcbdef`, `fbdcxa`))
+	strEqual(t, matchString(uniqueMatches(cont)), `(0, 5) (2, 3) (4, 2) (6, 0)`, `unique matches`)
+}
+
+func Test_LongestIncreasing(t *testing.T) {
+	strEqual(t, matchString(longestIncreasing(nil)), ``, `empty`)
+	strEqual(t, matchString(longestIncreasing([]match{
+		{0, 5}, {2, 3}, {4, 2}, {6, 0},
+	})), `(6, 0)`, `decreasing`)
+	strEqual(t, matchString(longestIncreasing([]match{
+		{0, 9}, {1, 4}, {2, 6}, {3, 12}, {4, 8}, {5, 7}, {6, 1}, {7, 7}, {8, 10},
+	})), `(1, 4) (2, 6) (7, 7) (8, 10)`, `mixed`)
+}
+
+func matchString(matches []match) string {
+	parts := make([]string, len(matches))
+	for i, m := range matches {
+		parts[i] = fmt.Sprintf(`(%d, %d)`, m.aIndex, m.bIndex)
+	}
+	return strings.Join(parts, ` `)
+}
+
+func strEqual(t *testing.T, value, exp, msg string) {
+	if value != exp {
+		t.Error(fmt.Sprint("Unexpected string value:",
+			"\n   Message:  ", msg,
+			"\n   Value:    ", value,
+			"\n   Expected: ", exp))
+	}
+}
+
+// checks the patience algorithm
+func check(t *testing.T, d container.Diff, a, b, exp string) {
+	col := collector.New()
+	cont := container.New(comparable.NewChar(a, b))
+	d.Diff(cont, col)
+	col.Finish()
+	if result := col.String(); exp != result {
+		t.Error("Patience returned unexpected result:",
+			"\n   Input A:  ", a,
+			"\n   Input B:  ", b,
+			"\n   Expected: ", exp,
+			"\n   Result:   ", result)
+	}
+}
+
+// checks the patience algorithm on lines
+func checkLines(t *testing.T, d container.Diff, a, b []string, exp string) {
+	col := collector.New()
+	cont := container.New(comparable.NewString(a, b))
+	d.Diff(cont, col)
+	col.Finish()
+	if result := col.String(); exp != result {
+		t.Error("Patience returned unexpected result:",
+			"\n   Input A:  ", a,
+			"\n   Input B:  ", b,
+			"\n   Expected: ", exp,
+			"\n   Result:   ", result)
+	}
+}
@@ -0,0 +1,146 @@
+package wagner
+
+import (
+	"github.com/Grant-Nelson/goDiff/internal/collector"
+	"github.com/Grant-Nelson/goDiff/internal/container"
+)
+
+// affineState is the kind of step which was last taken in an affine path.
+type affineState int
+
+const (
+	// matchState is a path which ends with an equal or substitution.
+	matchState affineState = iota
+
+	// removeState is a path which ends with a remove.
+	removeState
+
+	// addState is a path which ends with an add.
+	addState
+
+	// stateCount is the number of affine states.
+	stateCount
+)
+
+// affineSize is the amount of matrix space needed for the affine costs of the given container.
+// The affine costs use a matrix per state, each including the edges.
+func affineSize(cont *container.Container) int {
+	return int(stateCount) * (cont.ALength() + 1) * (cont.BLength() + 1)
+}
+
+// affineIndex gets the index into the cost matrix for the given state and indices.
+// The indices are offset by one so that zero is the edge before the first part.
+func affineIndex(state affineState, i, j, aLen, bLen int) int {
+	return i + (j+int(state)*(bLen+1))*(aLen+1)
+}
+
+// setAffineCosts will populate the cost matrices using Gotoh's algorithm.
+// Each state has its own matrix so that the gap open cost is only added
+// when a run of removes or adds is started.
+// Returns false if the container was canceled before the costs were finished.
+func (w *wagner) setAffineCosts(cont *container.Container) bool {
+	aLen := cont.ALength()
+	bLen := cont.BLength()
+	gapOpen := cont.GapOpenCost()
+
+	for j := 0; j <= bLen; j++ {
+		if cont.Canceled() {
+			return false
+		}
+
+		for i := 0; i <= aLen; i++ {
+			match, remove, add := container.Unreachable, container.Unreachable, container.Unreachable
+			if i == 0 && j == 0 {
+				match = 0
+			}
+			if i > 0 && j > 0 {
+				match = w.minAffine(i-1, j-1, aLen, bLen, 0, 0) + cont.SubstitionCost(i-1, j-1)
+			}
+			if i > 0 {
+				remove = container.Min3(
+					w.affineCost(matchState, i-1, j, aLen, bLen)+gapOpen,
+					w.affineCost(removeState, i-1, j, aLen, bLen),
+					w.affineCost(addState, i-1, j, aLen, bLen)+gapOpen) + cont.RemoveCost(i-1)
+			}
+			if j > 0 {
+				add = container.Min3(
+					w.affineCost(matchState, i, j-1, aLen, bLen)+gapOpen,
+					w.affineCost(removeState, i, j-1, aLen, bLen)+gapOpen,
+					w.affineCost(addState, i, j-1, aLen, bLen)) + cont.AddCost(j-1)
+			}
+			w.costs[affineIndex(matchState, i, j, aLen, bLen)] = container.Min2(match, container.Unreachable)
+			w.costs[affineIndex(removeState, i, j, aLen, bLen)] = container.Min2(remove, container.Unreachable)
+			w.costs[affineIndex(addState, i, j, aLen, bLen)] = container.Min2(add, container.Unreachable)
+		}
+	}
+	return true
+}
+
+// affineCost gets the cost in the matrix for the given state and indices.
+func (w *wagner) affineCost(state affineState, i, j, aLen, bLen int) int {
+	return w.costs[affineIndex(state, i, j, aLen, bLen)]
+}
+
+// minAffine gets the minimum cost of any state at the given indices.
+// The given remove and add costs are added to the costs from the remove and add states.
+func (w *wagner) minAffine(i, j, aLen, bLen, removeCost, addCost int) int {
+	return container.Min3(
+		w.affineCost(matchState, i, j, aLen, bLen),
+		w.affineCost(removeState, i, j, aLen, bLen)+removeCost,
+		w.affineCost(addState, i, j, aLen, bLen)+addCost)
+}
+
+// prevAffine determines which state to step back into from the given indices,
+// where the cost at those indices plus the given extra costs is the given cost.
+// If there is a tie the given preferred state is chosen, then match, add, and remove.
+func (w *wagner) prevAffine(cost, i, j, aLen, bLen int, preferred affineState, extra [stateCount]int) affineState {
+	order := [...]affineState{preferred, matchState, addState, removeState}
+	for _, state := range order {
+		if w.affineCost(state, i, j, aLen, bLen)+extra[state] == cost {
+			return state
+		}
+	}
+	return preferred
+}
+
+// walkAffinePath will walk through the cost matrices backwards to find the minimum affine path.
+// The steps for this path are added to the given collector.
+//
+// Runs of removes and adds are continued when there is a tie so that
+// the edits are grouped into fewer blocks.
+func (w *wagner) walkAffinePath(cont *container.Container, col *collector.Collector) {
+	aLen := cont.ALength()
+	bLen := cont.BLength()
+	gapOpen := cont.GapOpenCost()
+	walk := newWalker(cont, col)
+
+	i, j := aLen, bLen
+	state := w.prevAffine(w.minAffine(i, j, aLen, bLen, 0, 0), i, j, aLen, bLen, matchState, [stateCount]int{})
+	for walk.hasMore() {
+		cost := w.affineCost(state, i, j, aLen, bLen)
+		switch state {
+		case matchState:
+			if cont.Equals(i-1, j-1) {
+				walk.moveEqual()
+			} else {
+				walk.moveSubstitute()
+			}
+			cost -= cont.SubstitionCost(i-1, j-1)
+			i, j = i-1, j-1
+			state = w.prevAffine(cost, i, j, aLen, bLen, matchState, [stateCount]int{})
+
+		case removeState:
+			walk.moveA()
+			cost -= cont.RemoveCost(i - 1)
+			i--
+			state = w.prevAffine(cost, i, j, aLen, bLen, removeState, [stateCount]int{gapOpen, 0, gapOpen})
+
+		default:
+			walk.moveB()
+			cost -= cont.AddCost(j - 1)
+			j--
+			state = w.prevAffine(cost, i, j, aLen, bLen, addState, [stateCount]int{gapOpen, gapOpen, 0})
+		}
+	}
+	walk.finish()
+}
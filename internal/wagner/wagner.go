@@ -7,8 +7,20 @@ import (
 
 // wagner will perform a Wagner–Fischer diff on the given comparable.
 // The algorithm is a Wagner–Fischer's algorithm (https://en.wikipedia.org/wiki/Wagner%E2%80%93Fischer_algorithm).
+// When the costs have a gap open cost, Gotoh's algorithm is used instead
+// (https://doi.org/10.1016/0022-2836(82)90398-9) so that the costs are affine.
 type wagner struct {
+
+	// costs is the cost matrix for the parts of the container.
 	costs []int
+
+	// aEdge is the costs along the edge of the matrix before the first B part.
+	// These are the total costs for removing A up to and including each index.
+	aEdge []int
+
+	// bEdge is the costs along the edge of the matrix before the first A part.
+	// These are the total costs for adding B up to and including each index.
+	bEdge []int
 }
 
 // New creates a new Wagner–Fischer diff algorithm.
@@ -28,92 +40,201 @@ func (w *wagner) allocateMatrix(size int) {
 	w.costs = make([]int, size)
 }
 
+// allocateEdges will create the slices used for the edge costs if they are too small.
+func (w *wagner) allocateEdges(aLen, bLen int) {
+	if len(w.aEdge) < aLen {
+		w.aEdge = make([]int, aLen)
+	}
+	if len(w.bEdge) < bLen {
+		w.bEdge = make([]int, bLen)
+	}
+}
+
 // NoResizeNeeded determines if the diff algorithm can handle a container with
 // the amount of data inside of the given container.
 // This algorithm's cost matrix will be auto-resize if needed so this method
 // only indicates if the current matrix are large enough to not need reallocation.
 func (w *wagner) NoResizeNeeded(cont *container.Container) bool {
+	if cont.Affine() {
+		return len(w.costs) >= affineSize(cont)
+	}
 	return len(w.costs) >= cont.ALength()*cont.BLength()
 }
 
 // Diff performs the algorithm on the given container
 // and writes the results to the collector.
 func (w *wagner) Diff(cont *container.Container, col *collector.Collector) {
+	if cont.Affine() {
+		if size := affineSize(cont); len(w.costs) < size {
+			w.allocateMatrix(size)
+		}
+		if !w.setAffineCosts(cont) {
+			cont.Coarse(col)
+			return
+		}
+		w.walkAffinePath(cont, col)
+		return
+	}
+
 	if size := cont.ALength() * cont.BLength(); len(w.costs) < size {
 		w.allocateMatrix(size)
 	}
-	w.setCosts(cont)
+	w.allocateEdges(cont.ALength(), cont.BLength())
+	if !w.setCosts(cont) {
+		cont.Coarse(col)
+		return
+	}
 	w.walkPath(cont, col)
 }
 
 // setCosts will populate the part of the cost matrix which is needed by the given container.
 // The costs are based off of the equality of parts in the comparable in the given container.
-func (w *wagner) setCosts(cont *container.Container) {
+// Returns false if the container was canceled before the costs were finished.
+func (w *wagner) setCosts(cont *container.Container) bool {
+	if cont.Uniform() {
+		return w.setUniformCosts(cont)
+	}
+
+	aLen := cont.ALength()
+	bLen := cont.BLength()
+
+	for i, value := 0, 0; i < aLen; i++ {
+		value += cont.RemoveCost(i)
+		w.aEdge[i] = value
+	}
+
+	for j, value := 0, 0; j < bLen; j++ {
+		value += cont.AddCost(j)
+		w.bEdge[j] = value
+	}
+
+	for j, k := 0, 0; j < bLen; j++ {
+		if cont.Canceled() {
+			return false
+		}
+
+		addCost := cont.AddCost(j)
+		for i := 0; i < aLen; i, k = i+1, k+1 {
+			w.costs[k] = container.Min3(
+				w.getCost(i-1, j, aLen)+cont.RemoveCost(i),
+				w.getCost(i, j-1, aLen)+addCost,
+				w.getCost(i-1, j-1, aLen)+cont.SubstitionCost(i, j))
+		}
+	}
+	return true
+}
+
+// setUniformCosts will populate the part of the cost matrix which is needed by the given container
+// when the remove and add costs are the same for every index. This is the same as setCosts
+// but without looking up the edge and the remove and add costs for every entry.
+// Returns false if the container was canceled before the costs were finished.
+func (w *wagner) setUniformCosts(cont *container.Container) bool {
 	aLen := cont.ALength()
 	bLen := cont.BLength()
+	costs := cont.Costs()
+	removeCost, addCost := costs.Remove, costs.Add
+	equalCost, substitionCost := costs.Equal, costs.Substition
+	substitute := func(i, j int) int {
+		if cont.Equals(i, j) {
+			return equalCost
+		}
+		return substitionCost
+	}
+
+	for i := 0; i < aLen; i++ {
+		w.aEdge[i] = (i + 1) * removeCost
+	}
+
+	for j := 0; j < bLen; j++ {
+		w.bEdge[j] = (j + 1) * addCost
+	}
 
-	start := cont.SubstitionCost(0, 0)
-	w.costs[0] = start
+	if aLen <= 0 || bLen <= 0 {
+		return true
+	}
+	if cont.Canceled() {
+		return false
+	}
 
-	for i, value := 1, start; i < aLen; i++ {
-		value = container.Min2(value+1,
-			i+cont.SubstitionCost(i, 0))
+	// The first row is next to the B edge.
+	value := container.Min2(removeCost+addCost, substitute(0, 0))
+	w.costs[0] = value
+	for i := 1; i < aLen; i++ {
+		value = container.Min3(value+removeCost,
+			w.aEdge[i]+addCost,
+			w.aEdge[i-1]+substitute(i, 0))
 		w.costs[i] = value
 	}
 
-	for j, k, value := 1, aLen, start; j < bLen; j, k = j+1, k+aLen {
-		value = container.Min2(value+1,
-			j+cont.SubstitionCost(0, j))
+	for j, k := 1, aLen; j < bLen; j++ {
+		if cont.Canceled() {
+			return false
+		}
+
+		// The first entry of each row is next to the A edge.
+		value = container.Min3(w.bEdge[j]+removeCost,
+			w.costs[k-aLen]+addCost,
+			w.bEdge[j-1]+substitute(0, j))
 		w.costs[k] = value
-	}
 
-	for j, k, k2, k3 := 1, aLen+1, 1, 0; j < bLen; j, k, k2, k3 = j+1, k+1, k2+1, k3+1 {
-		for i, value := 1, w.costs[k-1]; i < aLen; i, k, k2, k3 = i+1, k+1, k2+1, k3+1 {
-			value = container.Min3(value+1,
-				w.costs[k2]+1,
-				w.costs[k3]+cont.SubstitionCost(i, j))
-			w.costs[k] = value
+		for i, k2, k3 := 1, k-aLen+1, k-aLen; i < aLen; i, k2, k3 = i+1, k2+1, k3+1 {
+			value = container.Min3(value+removeCost,
+				w.costs[k2]+addCost,
+				w.costs[k3]+substitute(i, j))
+			w.costs[k2+aLen] = value
 		}
+		k += aLen
 	}
+	return true
 }
 
 // getCost gets the cost value at the given indices.
 // If the indices are out-of-bounds the edge cost will be returned.
 func (w *wagner) getCost(i, j, aLen int) int {
 	if i < 0 {
-		return j + 1
+		if j < 0 {
+			return 0
+		}
+		return w.bEdge[j]
 	}
 	if j < 0 {
-		return i + 1
+		return w.aEdge[i]
 	}
 	return w.costs[i+j*aLen]
 }
 
 // walkPath will walk through the cost matrix backwards to find the minimum Levenshtein path.
 // The steps for this path are added to the given collector.
+//
+// At each step only the moves which are consistent with the cost are considered.
+// Of those the move from the lowest cost is taken, if there is a tie the move is
+// chosen in the order of equal, add, remove, then substitute.
 func (w *wagner) walkPath(cont *container.Container, col *collector.Collector) {
 	aLen := cont.ALength()
 	walk := newWalker(cont, col)
 	for walk.hasMore() {
-		aCost := w.getCost(walk.i-1, walk.j, aLen)
-		bCost := w.getCost(walk.i, walk.j-1, aLen)
-		cCost := w.getCost(walk.i-1, walk.j-1, aLen)
-		minCost := container.Min3(aCost, bCost, cCost)
+		i, j := walk.i, walk.j
+		cost := w.getCost(i, j, aLen)
+		aCost := w.getCost(i-1, j, aLen)
+		bCost := w.getCost(i, j-1, aLen)
+		cCost := w.getCost(i-1, j-1, aLen)
+		equal := cont.Equals(i, j)
+		cMatch := cCost+cont.SubstitionCost(i, j) == cost
 
 		var curMove walkerStep
-		if aCost == minCost {
-			curMove = walk.moveA
-		}
-		if bCost == minCost {
-			curMove = walk.moveB
-		}
-		if cCost == minCost {
-			if cont.Equals(walk.i, walk.j) {
-				curMove = walk.moveEqual
-			} else if curMove == nil {
-				curMove = walk.moveSubstitute
+		minCost := 0
+		consider := func(valid bool, prevCost int, move walkerStep) {
+			if valid && (curMove == nil || prevCost < minCost) {
+				curMove, minCost = move, prevCost
 			}
 		}
+		consider(equal && cMatch, cCost, walk.moveEqual)
+		consider(bCost+cont.AddCost(j) == cost, bCost, walk.moveB)
+		consider(aCost+cont.RemoveCost(i) == cost, aCost, walk.moveA)
+		consider(!equal && cMatch, cCost, walk.moveSubstitute)
+		if curMove == nil {
+			curMove = walk.moveSubstitute
+		}
 
 		curMove()
 	}
@@ -1,7 +1,9 @@
 package wagner
 
 import (
+	"context"
 	"fmt"
+	"math/rand"
 	"strings"
 	"testing"
 
@@ -43,6 +45,77 @@ func noResizeNeeded(d container.Diff, a, b int) bool {
 	return d.NoResizeNeeded(container.New(comp))
 }
 
+func Test_Wagner_Costs(t *testing.T) {
+	costs := container.DefaultCosts()
+	costs.RemoveFunc = func(aIndex int) int {
+		if aIndex == 2 {
+			return 10
+		}
+		return 1
+	}
+	cheapX := container.DefaultCosts()
+	cheapX.Add, cheapX.Remove, cheapX.Substition = 5, 5, 10
+	cheapX.RemoveFunc = func(aIndex int) int {
+		if aIndex%2 == 1 {
+			return 0
+		}
+		return 5
+	}
+	checkCosts(t, New(-1), `ABC`, `ADB`, costs, `=1 -2 +2`)
+	checkCosts(t, New(-1), `AxBxC`, `ABC`, cheapX, `=1 -1 =1 -1 =1`)
+	checkCosts(t, New(-1), `BA`, `AB`, cheapX, `+1 =1 -1`)
+}
+
+func Test_Wagner_UniformCosts(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	randText := func(length int) string {
+		text := make([]byte, length)
+		for i := range text {
+			text[i] = byte('a' + r.Intn(4))
+		}
+		return string(text)
+	}
+	weighted := container.DefaultCosts()
+	weighted.Remove, weighted.Add, weighted.Substition = 2, 3, 4
+
+	// Setting a cost function, even one which returns the constant cost,
+	// makes the costs be looked up for every entry instead of using the uniform costs.
+	for _, uniform := range []*container.Costs{container.DefaultCosts(), weighted} {
+		looked := *uniform
+		remove := uniform.Remove
+		looked.RemoveFunc = func(aIndex int) int { return remove }
+		for i := 0; i < 20; i++ {
+			a, b := randText(r.Intn(30)), randText(r.Intn(30))
+			exp := diffCosts(New(-1), a, b, &looked)
+			checkCosts(t, New(-1), a, b, uniform, exp)
+		}
+	}
+}
+
+func Test_Wagner_Affine(t *testing.T) {
+	costs := container.DefaultCosts()
+	costs.GapOpen = 3
+	costs.Substition = 8
+	checkCosts(t, New(-1), `kitten`, `sitting`, costs, `-6 +7`)
+	checkCosts(t, New(-1), `ABCDEFG`, `AxCxExG`, costs, `=1 -5 +5 =1`)
+	checkCosts(t, New(-1), `ABxCDxEF`, `ABCDEF`, costs, `=2 -1 =2 -1 =2`)
+}
+
+func Test_Wagner_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	d := New(-1)
+	col := collector.New()
+	cont := container.NewContext(ctx, comparable.NewChar(`kitten kitten`, `sitting sitting`), nil)
+	d.Diff(cont, col)
+	col.Finish()
+	boolEqual(t, col.Approximate(), true, `approximate`)
+	if result := col.String(); result != `-13 +15` {
+		t.Error("Wagner returned unexpected result for a canceled diff: ", result)
+	}
+}
+
 func boolEqual(t *testing.T, value, exp bool, msg string) {
 	if value != exp {
 		t.Error(fmt.Sprint("Unexpected boolean value:",
@@ -75,3 +148,26 @@ func check(t *testing.T, d container.Diff, a, b, exp string) {
 			"\n   Result:   ", result)
 	}
 }
+
+// diffCosts gets the result string of the diff algorithm with custom costs.
+func diffCosts(d container.Diff, a, b string, costs *container.Costs) string {
+	col := collector.New()
+	d.Diff(container.NewCosts(comparable.NewChar(a, b), costs), col)
+	col.Finish()
+	return col.String()
+}
+
+// checks the diff algorithm with custom costs
+func checkCosts(t *testing.T, d container.Diff, a, b string, costs *container.Costs, exp string) {
+	col := collector.New()
+	cont := container.NewCosts(comparable.NewChar(a, b), costs)
+	d.Diff(cont, col)
+	col.Finish()
+	if result := col.String(); exp != result {
+		t.Error("Wagner returned unexpected result:",
+			"\n   Input A:  ", a,
+			"\n   Input B:  ", b,
+			"\n   Expected: ", exp,
+			"\n   Result:   ", result)
+	}
+}
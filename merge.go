@@ -5,6 +5,10 @@ import (
 	"github.com/Grant-Nelson/goDiff/step"
 )
 
+// mergeMarkerSize is the number of characters in the markers written by Merge and MergeCustom.
+// These have always been wider than git's markers, so they are kept to not change the output.
+const mergeMarkerSize = 8
+
 // Merge gets the labelled difference between the two slices
 // using a similar output to the git merge differences output.
 // This will use the default diff configuration to perform the diff.
@@ -15,73 +19,61 @@ func Merge(a, b []string) []string {
 // MergeCustom gets the labelled difference between the two slices
 // using a similar output to the git merge differences output.
 // This was can use any given diff algorithm.
+// The conflict markers are 8 characters wide, use NewMerge to label the conflicts with other options.
+// If the diff fails the result is empty, use NewMerge to get the error.
 func MergeCustom(diff Algorithm, a, b []string) []string {
+	return NewMerge(diff, a, b).Lines(&MergeOptions{MarkerSize: mergeMarkerSize})
+}
+
+// NewMerge performs a two-way merge of the two slices using the given diff algorithm.
+// The equal parts are unchanged regions and every difference is a conflict region,
+// since without a base it is unknown which side made the change.
+// If the diff algorithm is nil the default diff configuration is used.
+// If the diff fails the merge result has no regions and MergeResult.Err returns the error.
+func NewMerge(diff Algorithm, a, b []string) *MergeResult {
 	if diff == nil {
 		diff = DefaultDiff()
 	}
 	path := diff(comparable.NewString(a, b))
+	if err := path.Err(); err != nil {
+		return &MergeResult{err: err}
+	}
 
-	const (
-		startChange  = "<<<<<<<<"
-		middleChange = "========"
-		endChange    = ">>>>>>>>"
-	)
-
-	result := make([]string, 0, path.Total()+path.Count()*2+1)
+	regions := make([]MergeRegion, 0, path.Count())
 	aIndex, bIndex := 0, 0
-
-	prevState := step.Equal
 	path.Read(func(stepType step.Type, count int) {
-		switch stepType {
-		case step.Equal:
-			switch prevState {
-			case step.Added:
-				result = append(result, endChange)
-			case step.Removed:
-				result = append(result, middleChange)
-				result = append(result, endChange)
-			}
-			for i := count - 1; i >= 0; i-- {
-				result = append(result, a[aIndex])
-				aIndex++
-				bIndex++
-			}
-
-		case step.Added:
-			switch prevState {
-			case step.Equal:
-				result = append(result, startChange)
-				result = append(result, middleChange)
-			case step.Removed:
-				result = append(result, middleChange)
-			}
-			for i := count - 1; i >= 0; i-- {
-				result = append(result, b[bIndex])
-				bIndex++
-			}
+		if stepType == step.Equal {
+			regions = append(regions, MergeRegion{
+				Kind: RegionUnchanged,
+				A:    a[aIndex : aIndex+count],
+				B:    b[bIndex : bIndex+count],
+			})
+			aIndex += count
+			bIndex += count
+			return
+		}
 
-		case step.Removed:
-			switch prevState {
-			case step.Equal:
-				result = append(result, startChange)
-			case step.Added:
-				result = append(result, endChange)
-				result = append(result, startChange)
-			}
-			for i := count - 1; i >= 0; i-- {
-				result = append(result, a[aIndex])
-				aIndex++
-			}
+		// Removed parts are labelled before added parts, so a removed part
+		// which follows an added part starts a new conflict.
+		last := len(regions) - 1
+		if last < 0 || regions[last].Kind != RegionConflict ||
+			(stepType == step.Removed && len(regions[last].B) > 0) {
+			regions = append(regions, MergeRegion{
+				Kind: RegionConflict,
+				A:    a[aIndex:aIndex],
+				B:    b[bIndex:bIndex],
+			})
+		}
+		region := &regions[len(regions)-1]
+		if stepType == step.Added {
+			bIndex += count
+			region.B = b[bIndex-len(region.B)-count : bIndex]
+		} else {
+			aIndex += count
+			region.A = a[aIndex-len(region.A)-count : aIndex]
 		}
-		prevState = stepType
 	})
-
-	switch prevState {
-	case step.Added:
-		result = append(result, endChange)
-	case step.Removed:
-		result = append(result, middleChange)
-		result = append(result, endChange)
+	return &MergeResult{
+		Regions: regions,
 	}
-	return result
 }
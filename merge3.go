@@ -0,0 +1,205 @@
+package godiff
+
+import (
+	"github.com/Grant-Nelson/goDiff/comparable"
+	"github.com/Grant-Nelson/goDiff/step"
+)
+
+// mergeChange is a change, from one of the sides of a three-way merge, to a range of the base.
+// The range of the side which replaces the range of the base is also kept.
+type mergeChange struct {
+	baseStart, baseEnd int
+	sideStart, sideEnd int
+}
+
+// Merge3 performs a three-way merge of the changes made to the base lines by our side and
+// by their side. Changes made by only one side are applied. Where both sides have made different
+// changes to the same part of the base the conflicting changes are labelled in a similar output
+// to the git merge conflict output, with ours between the start and middle markers
+// and theirs between the middle and end markers.
+// This will use the default diff configuration to perform the diffs.
+func Merge3(base, ours, theirs []string) []string {
+	return Merge3Options(base, ours, theirs, nil)
+}
+
+// Merge3Custom performs a three-way merge of the changes made to the base lines by our side and
+// by their side. Changes made by only one side are applied. Where both sides have made different
+// changes to the same part of the base the conflicting changes are labelled in a similar output
+// to the git merge conflict output, with ours between the start and middle markers
+// and theirs between the middle and end markers.
+// This can use any given diff algorithm.
+// If either diff fails the result is empty, use NewMerge3 to get the error.
+func Merge3Custom(diff Algorithm, base, ours, theirs []string) []string {
+	return Merge3Options(base, ours, theirs, &MergeOptions{Diff: diff})
+}
+
+// Merge3Options performs a three-way merge of the changes made to the base lines by our side and
+// by their side. Changes made by only one side are applied. Where both sides have made different
+// changes to the same part of the base the conflicting changes are labelled
+// using the style and labels from the given options.
+// If the options are nil the default options are used.
+// If either diff fails the result is empty, use NewMerge3 to get the error.
+func Merge3Options(base, ours, theirs []string, options *MergeOptions) []string {
+	return NewMerge3(base, ours, theirs, options).Lines(options)
+}
+
+// NewMerge3 performs a three-way merge of the changes made to the base lines by our side, A, and
+// by their side, B. The base is split into regions which are unchanged, changed by only one side,
+// or changed by both sides. Where both sides made the same change the region is taken from A.
+// Conflicts are resolved with the resolver from the options, if there is one.
+// If the options are nil the default options are used.
+// If either diff fails the merge result has no regions and MergeResult.Err returns the error.
+func NewMerge3(base, ours, theirs []string, options *MergeOptions) *MergeResult {
+	diff := DefaultDiff()
+	if options != nil && options.Diff != nil {
+		diff = options.Diff
+	}
+	oursChanges, err := mergeChanges(diff(comparable.NewString(base, ours)))
+	if err != nil {
+		return &MergeResult{threeWay: true, err: err}
+	}
+	theirsChanges, err := mergeChanges(diff(comparable.NewString(base, theirs)))
+	if err != nil {
+		return &MergeResult{threeWay: true, err: err}
+	}
+
+	regions := []MergeRegion{}
+	baseIndex, i, j := 0, 0, 0
+	for i < len(oursChanges) || j < len(theirsChanges) {
+		// Start the group with the change which starts first in the base,
+		// then grow it with any changes from either side which overlap the group.
+		var start, end int
+		if j >= len(theirsChanges) || (i < len(oursChanges) && oursChanges[i].baseStart <= theirsChanges[j].baseStart) {
+			start, end = oursChanges[i].baseStart, oursChanges[i].baseEnd
+		} else {
+			start, end = theirsChanges[j].baseStart, theirsChanges[j].baseEnd
+		}
+		oursFirst, theirsFirst := i, j
+		for grown := true; grown; {
+			grown = false
+			for i < len(oursChanges) && overlaps(oursChanges[i], start, end) {
+				end = maxInt(end, oursChanges[i].baseEnd)
+				i, grown = i+1, true
+			}
+			for j < len(theirsChanges) && overlaps(theirsChanges[j], start, end) {
+				end = maxInt(end, theirsChanges[j].baseEnd)
+				j, grown = j+1, true
+			}
+		}
+
+		if baseIndex < start {
+			regions = append(regions, unchangedRegion(base[baseIndex:start]))
+		}
+		baseIndex = end
+
+		region := MergeRegion{
+			Base: base[start:end],
+			A:    sideLines(base, ours, oursChanges[oursFirst:i], start, end),
+			B:    sideLines(base, theirs, theirsChanges[theirsFirst:j], start, end),
+		}
+		switch {
+		case i == oursFirst:
+			region.Kind = RegionFromB
+		case j == theirsFirst, equalLines(region.A, region.B):
+			region.Kind = RegionFromA
+		default:
+			region.Kind = RegionConflict
+		}
+		regions = append(regions, region)
+	}
+
+	if baseIndex < len(base) {
+		regions = append(regions, unchangedRegion(base[baseIndex:]))
+	}
+	result := &MergeResult{
+		Regions:  regions,
+		threeWay: true,
+	}
+	if options != nil {
+		result.Resolve(options.Resolve)
+	}
+	return result
+}
+
+// unchangedRegion creates a region where the given lines are the same on all sides.
+func unchangedRegion(lines []string) MergeRegion {
+	return MergeRegion{
+		Kind: RegionUnchanged,
+		Base: lines,
+		A:    lines,
+		B:    lines,
+	}
+}
+
+// mergeChanges gets the changes to the base, A, from the given diff results.
+// Each change is a run of added and removed steps between equal steps.
+// If the diff failed the error from Results.Err is returned.
+func mergeChanges(path Results) ([]mergeChange, error) {
+	if err := path.Err(); err != nil {
+		return nil, err
+	}
+	changes := []mergeChange{}
+	aIndex, bIndex := 0, 0
+	inChange := false
+	path.Read(func(stepType step.Type, count int) {
+		if stepType == step.Equal {
+			aIndex += count
+			bIndex += count
+			inChange = false
+			return
+		}
+
+		if !inChange {
+			changes = append(changes, mergeChange{
+				baseStart: aIndex,
+				baseEnd:   aIndex,
+				sideStart: bIndex,
+				sideEnd:   bIndex,
+			})
+			inChange = true
+		}
+		if stepType == step.Added {
+			bIndex += count
+		} else {
+			aIndex += count
+		}
+		last := &changes[len(changes)-1]
+		last.baseEnd, last.sideEnd = aIndex, bIndex
+	})
+	return changes, nil
+}
+
+// overlaps determines if the given change overlaps the given range of the base.
+// Changes which only touch the range overlap if the change or the range is an insertion,
+// since otherwise the order of the inserted lines would be ambiguous.
+func overlaps(change mergeChange, start, end int) bool {
+	if change.baseStart < end && start < change.baseEnd {
+		return true
+	}
+	insertion := change.baseStart == change.baseEnd || start == end
+	return insertion && change.baseStart <= end && start <= change.baseEnd
+}
+
+// sideLines gets the lines from a side which replace the given range of the base.
+// The given changes are the side's changes inside of the range.
+// If there are no changes the side is the same as the base for that range.
+func sideLines(base, side []string, changes []mergeChange, start, end int) []string {
+	if len(changes) <= 0 {
+		return base[start:end]
+	}
+	first, last := changes[0], changes[len(changes)-1]
+	return side[first.sideStart-(first.baseStart-start) : last.sideEnd+(end-last.baseEnd)]
+}
+
+// equalLines determines if the two given slices of lines are the same.
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
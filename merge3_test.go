@@ -0,0 +1,124 @@
+package godiff
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func Test_Merge3_NoConflicts(t *testing.T) {
+	base := lines(`1`, `2`, `3`, `4`, `5`, `6`, `7`)
+	checkSlices(t, Merge3(base,
+		lines(`one`, `2`, `3`, `4`, `5`, `6`, `7`),
+		lines(`1`, `2`, `3`, `4`, `5`, `6`, `7`, `8`)),
+		lines(`one`, `2`, `3`, `4`, `5`, `6`, `7`, `8`))
+
+	// Changes to neighbouring lines don't conflict.
+	checkSlices(t, Merge3(base,
+		lines(`1`, `2`, `three`, `4`, `5`, `6`, `7`),
+		lines(`1`, `2`, `3`, `four`, `5`, `6`, `7`)),
+		lines(`1`, `2`, `three`, `four`, `5`, `6`, `7`))
+
+	// The same change on both sides doesn't conflict.
+	checkSlices(t, Merge3(base,
+		lines(`1`, `2`, `3`, `5`, `6`, `seven`),
+		lines(`1`, `2`, `3`, `5`, `6`, `7`)),
+		lines(`1`, `2`, `3`, `5`, `6`, `seven`))
+	checkSlices(t, Merge3(base,
+		lines(`1`, `2`, `3`, `4`, `4.5`, `5`, `6`, `7`),
+		lines(`1`, `2`, `3`, `4`, `4.5`, `5`, `6`, `7`)),
+		lines(`1`, `2`, `3`, `4`, `4.5`, `5`, `6`, `7`))
+}
+
+func Test_Merge3_Conflicts(t *testing.T) {
+	base := lines(`1`, `2`, `3`, `4`, `5`)
+	checkSlices(t, Merge3(base,
+		lines(`1`, `two`, `3`, `4`, `5`),
+		lines(`1`, `TWO`, `3`, `4`, `five`)),
+		lines(`1`,
+			`<<<<<<<`,
+			`two`,
+			`=======`,
+			`TWO`,
+			`>>>>>>>`,
+			`3`, `4`, `five`))
+
+	// Overlapping changes are combined into one conflict.
+	checkSlices(t, Merge3(base,
+		lines(`1`, `two`, `three`, `4`, `5`),
+		lines(`1`, `2`, `THREE`, `FOUR`, `5`)),
+		lines(`1`,
+			`<<<<<<<`,
+			`two`,
+			`three`,
+			`4`,
+			`=======`,
+			`2`,
+			`THREE`,
+			`FOUR`,
+			`>>>>>>>`,
+			`5`))
+
+	// Insertions at the same place conflict.
+	checkSlices(t, Merge3(base,
+		lines(`1`, `2`, `ours`, `3`, `4`, `5`),
+		lines(`1`, `2`, `theirs`, `3`, `4`, `5`)),
+		lines(`1`, `2`,
+			`<<<<<<<`,
+			`ours`,
+			`=======`,
+			`theirs`,
+			`>>>>>>>`,
+			`3`, `4`, `5`))
+
+	// An insertion next to a removal conflicts.
+	checkSlices(t, Merge3(base,
+		lines(`1`, `2`, `ours`, `3`, `4`, `5`),
+		lines(`1`, `2`, `4`, `5`)),
+		lines(`1`, `2`,
+			`<<<<<<<`,
+			`ours`,
+			`3`,
+			`=======`,
+			`>>>>>>>`,
+			`4`, `5`))
+}
+
+func Test_Merge3_Custom(t *testing.T) {
+	base := lines(`a`, `b`, `c`, `d`)
+	ours := lines(`a`, `B`, `c`, `d`)
+	theirs := lines(`a`, `b`, `c`, `D`)
+	for _, diff := range []Algorithm{HirschbergDiff(-1, true), WagnerDiff(-1), MyersDiff(-1), PatienceDiff(nil), HistogramDiff(-1, true, -1)} {
+		checkSlices(t, Merge3Custom(diff, base, ours, theirs), lines(`a`, `B`, `c`, `D`))
+	}
+}
+
+func Test_Merge3_OneSided(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	randLines := func() []string {
+		result := make([]string, r.Intn(10))
+		for i := range result {
+			result[i] = string(rune('a' + r.Intn(4)))
+		}
+		return result
+	}
+	for i := 0; i < 200; i++ {
+		base, side := randLines(), randLines()
+		checkMerge3(t, base, side, base, side)
+		checkMerge3(t, base, base, side, side)
+		checkMerge3(t, base, side, side, side)
+	}
+}
+
+// checkMerge3 checks the three-way merge of the given lines.
+func checkMerge3(t *testing.T, base, ours, theirs, exp []string) {
+	result := Merge3(base, ours, theirs)
+	if strings.Join(result, ",") != strings.Join(exp, ",") {
+		t.Error("Merge3 returned unexpected result:",
+			"\n   Base:     ", strings.Join(base, ","),
+			"\n   Ours:     ", strings.Join(ours, ","),
+			"\n   Theirs:   ", strings.Join(theirs, ","),
+			"\n   Expected: ", strings.Join(exp, ","),
+			"\n   Result:   ", strings.Join(result, ","))
+	}
+}
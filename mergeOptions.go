@@ -0,0 +1,130 @@
+package godiff
+
+import "strings"
+
+// DefaultMarkerSize is the default number of characters in a conflict marker, the same as git.
+const DefaultMarkerSize = 7
+
+const (
+	// startConflict is the marker character for the start of a conflict, before our lines.
+	startConflict = "<"
+
+	// baseConflict is the marker character between our lines and the base lines of a conflict.
+	baseConflict = "|"
+
+	// middleConflict is the marker character between our lines, or the base lines, and their lines of a conflict.
+	middleConflict = "="
+
+	// endConflict is the marker character for the end of a conflict, after their lines.
+	endConflict = ">"
+)
+
+type (
+	// ConflictStyle is the style used to label the conflicts in a three-way merge.
+	ConflictStyle int
+
+	// MergeOptions are the options for performing a three-way merge.
+	MergeOptions struct {
+
+		// Diff is the algorithm used to diff the base against each side.
+		// If nil the default diff configuration is used.
+		Diff Algorithm
+
+		// Style is the style used to label the conflicts.
+		Style ConflictStyle
+
+		// MarkerSize is the number of characters in each conflict marker.
+		// If zero or less DefaultMarkerSize is used, e.g. "<<<<<<<".
+		MarkerSize int
+
+		// OursLabel is added after the start marker of a conflict, e.g. "<<<<<<< ours.go".
+		OursLabel string
+
+		// BaseLabel is added after the base marker of a conflict when the base section is shown.
+		BaseLabel string
+
+		// TheirsLabel is added after the end marker of a conflict.
+		TheirsLabel string
+
+		// Resolve is the strategy for automatically resolving conflicts,
+		// such as ResolveOurs, ResolveTheirs, ResolveUnion, or a custom callback.
+		// If nil the conflicts are left unresolved and are labelled.
+		Resolve Resolver
+	}
+)
+
+const (
+	// MergeStyle labels a conflict with our lines and their lines,
+	// similar to git's default "merge" conflict style.
+	MergeStyle ConflictStyle = iota
+
+	// Diff3Style labels a conflict with our lines, the base lines, and their lines,
+	// similar to git's "diff3" conflict style.
+	Diff3Style
+
+	// ZDiff3Style is like Diff3Style except the lines at the start and end of the
+	// conflict which are the same in our lines and their lines are moved out of the conflict,
+	// similar to git's "zdiff3" conflict style.
+	ZDiff3Style
+)
+
+// String gets the name of the conflict style.
+func (s ConflictStyle) String() string {
+	switch s {
+	case MergeStyle:
+		return `merge`
+	case Diff3Style:
+		return `diff3`
+	case ZDiff3Style:
+		return `zdiff3`
+	default:
+		return `unknown`
+	}
+}
+
+// marker gets the given conflict marker character repeated to the marker size
+// followed by the given label, if there is a label.
+func (o *MergeOptions) marker(char, label string) string {
+	size := o.MarkerSize
+	if size <= 0 {
+		size = DefaultMarkerSize
+	}
+	marker := strings.Repeat(char, size)
+	if len(label) > 0 {
+		return marker + ` ` + label
+	}
+	return marker
+}
+
+// appendConflict appends the labelled lines for the given conflict to the given result.
+// The base section is only added when the conflict is from a three-way merge.
+func (o *MergeOptions) appendConflict(result []string, region MergeRegion, threeWay bool) []string {
+	ours, theirs := region.A, region.B
+	var suffix []string
+	if o.Style == ZDiff3Style {
+		prefix := 0
+		for prefix < len(ours) && prefix < len(theirs) && ours[prefix] == theirs[prefix] {
+			prefix++
+		}
+		result = append(result, ours[:prefix]...)
+		ours, theirs = ours[prefix:], theirs[prefix:]
+
+		after := 0
+		for after < len(ours) && after < len(theirs) && ours[len(ours)-1-after] == theirs[len(theirs)-1-after] {
+			after++
+		}
+		suffix = ours[len(ours)-after:]
+		ours, theirs = ours[:len(ours)-after], theirs[:len(theirs)-after]
+	}
+
+	result = append(result, o.marker(startConflict, o.OursLabel))
+	result = append(result, ours...)
+	if threeWay && (o.Style == Diff3Style || o.Style == ZDiff3Style) {
+		result = append(result, o.marker(baseConflict, o.BaseLabel))
+		result = append(result, region.Base...)
+	}
+	result = append(result, o.marker(middleConflict, ``))
+	result = append(result, theirs...)
+	result = append(result, o.marker(endConflict, o.TheirsLabel))
+	return append(result, suffix...)
+}
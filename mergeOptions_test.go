@@ -0,0 +1,115 @@
+package godiff
+
+import "testing"
+
+func Test_MergeOptions_Styles(t *testing.T) {
+	base := lines(`1`, `2`, `3`, `4`)
+	ours := lines(`1`, `x`, `two`, `y`, `4`)
+	theirs := lines(`1`, `x`, `TWO`, `y`, `4`)
+
+	checkSlices(t, Merge3Options(base, ours, theirs, &MergeOptions{Style: MergeStyle}),
+		lines(`1`,
+			`<<<<<<<`,
+			`x`,
+			`two`,
+			`y`,
+			`=======`,
+			`x`,
+			`TWO`,
+			`y`,
+			`>>>>>>>`,
+			`4`))
+
+	checkSlices(t, Merge3Options(base, ours, theirs, &MergeOptions{Style: Diff3Style}),
+		lines(`1`,
+			`<<<<<<<`,
+			`x`,
+			`two`,
+			`y`,
+			`|||||||`,
+			`2`,
+			`3`,
+			`=======`,
+			`x`,
+			`TWO`,
+			`y`,
+			`>>>>>>>`,
+			`4`))
+
+	checkSlices(t, Merge3Options(base, ours, theirs, &MergeOptions{Style: ZDiff3Style}),
+		lines(`1`,
+			`x`,
+			`<<<<<<<`,
+			`two`,
+			`|||||||`,
+			`2`,
+			`3`,
+			`=======`,
+			`TWO`,
+			`>>>>>>>`,
+			`y`,
+			`4`))
+}
+
+func Test_MergeOptions_ZDiff3Overlap(t *testing.T) {
+	// The common lines at the start and end must not overlap.
+	checkSlices(t, Merge3Options(lines(`1`, `2`), lines(`a`, `a`), lines(`a`, `a`, `a`), &MergeOptions{Style: ZDiff3Style}),
+		lines(`a`, `a`,
+			`<<<<<<<`,
+			`|||||||`,
+			`1`,
+			`2`,
+			`=======`,
+			`a`,
+			`>>>>>>>`))
+}
+
+func Test_MergeOptions_Labels(t *testing.T) {
+	options := &MergeOptions{
+		Diff:        MyersDiff(-1),
+		Style:       Diff3Style,
+		OursLabel:   `ours.go`,
+		BaseLabel:   `base.go`,
+		TheirsLabel: `theirs.go`,
+	}
+	checkSlices(t, Merge3Options(lines(`a`), lines(`b`), lines(`c`), options),
+		lines(
+			`<<<<<<< ours.go`,
+			`b`,
+			`||||||| base.go`,
+			`a`,
+			`=======`,
+			`c`,
+			`>>>>>>> theirs.go`))
+}
+
+func Test_MergeOptions_MarkerSize(t *testing.T) {
+	options := &MergeOptions{
+		Style:       Diff3Style,
+		MarkerSize:  10,
+		OursLabel:   `ours.go`,
+		TheirsLabel: `theirs.go`,
+	}
+	checkSlices(t, Merge3Options(lines(`a`), lines(`b`), lines(`c`), options),
+		lines(
+			`<<<<<<<<<< ours.go`,
+			`b`,
+			`||||||||||`,
+			`a`,
+			`==========`,
+			`c`,
+			`>>>>>>>>>> theirs.go`))
+
+	// The two-way merge keeps its wider markers.
+	checkSlices(t, Merge(lines(`a`), lines(`b`)),
+		lines(`<<<<<<<<`, `a`, `========`, `b`, `>>>>>>>>`))
+	checkSlices(t, NewMerge(nil, lines(`a`), lines(`b`)).Lines(nil),
+		lines(`<<<<<<<`, `a`, `=======`, `b`, `>>>>>>>`))
+}
+
+func Test_MergeOptions_String(t *testing.T) {
+	strEqual(t, MergeStyle.String(), `merge`, `MergeStyle`)
+	strEqual(t, Diff3Style.String(), `diff3`, `Diff3Style`)
+	strEqual(t, ZDiff3Style.String(), `zdiff3`, `ZDiff3Style`)
+	strEqual(t, ConflictStyle(7).String(), `unknown`, `ConflictStyle(7)`)
+}
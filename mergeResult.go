@@ -0,0 +1,171 @@
+package godiff
+
+type (
+	// RegionKind indicates how a region of a merge was resolved.
+	RegionKind int
+
+	// MergeRegion is a part of a merge with the lines from the base and each side.
+	// For a two-way merge there is no base so the base lines are always empty.
+	MergeRegion struct {
+
+		// Kind indicates how this region was resolved.
+		Kind RegionKind
+
+		// Base is the lines from the base for this region.
+		Base []string
+
+		// A is the lines from A, or our side for a three-way merge, for this region.
+		A []string
+
+		// B is the lines from B, or their side for a three-way merge, for this region.
+		B []string
+
+		// Resolved is the lines which a conflict was resolved to. This is only set for resolved regions.
+		Resolved []string
+	}
+
+	// Resolver is a strategy for automatically resolving conflicts in a merge.
+	// The resolver is given each conflict region and returns the lines to replace the conflict with
+	// and true, or false if the conflict should be left unresolved.
+	Resolver func(region MergeRegion) ([]string, bool)
+
+	// MergeResult is the result of a merge as an ordered list of regions.
+	MergeResult struct {
+
+		// Regions are the parts of the merge in order.
+		Regions []MergeRegion
+
+		// threeWay indicates the merge had a base.
+		threeWay bool
+
+		// err is the error from a diff which the merge failed with.
+		err error
+	}
+)
+
+const (
+	// RegionUnchanged indicates the lines in the region are the same on all sides.
+	RegionUnchanged RegionKind = iota
+
+	// RegionFromA indicates the lines from A are taken. For a three-way merge this is used
+	// when only our side changed the region or both sides made the same change.
+	RegionFromA
+
+	// RegionFromB indicates the lines from B are taken.
+	// For a three-way merge this is used when only their side changed the region.
+	RegionFromB
+
+	// RegionConflict indicates the sides have different lines and both were changed.
+	RegionConflict
+
+	// RegionResolved indicates a conflict which was resolved by a resolver.
+	RegionResolved
+)
+
+var (
+	// ResolveOurs resolves conflicts by taking the lines from A, or our side for a three-way merge.
+	ResolveOurs Resolver = func(region MergeRegion) ([]string, bool) {
+		return region.A, true
+	}
+
+	// ResolveTheirs resolves conflicts by taking the lines from B, or their side for a three-way merge.
+	ResolveTheirs Resolver = func(region MergeRegion) ([]string, bool) {
+		return region.B, true
+	}
+
+	// ResolveUnion resolves conflicts by taking the lines from both sides, with the lines from A,
+	// or our side for a three-way merge, first.
+	ResolveUnion Resolver = func(region MergeRegion) ([]string, bool) {
+		lines := make([]string, 0, len(region.A)+len(region.B))
+		lines = append(lines, region.A...)
+		return append(lines, region.B...), true
+	}
+)
+
+// String gets the name of the region kind.
+func (k RegionKind) String() string {
+	switch k {
+	case RegionUnchanged:
+		return `unchanged`
+	case RegionFromA:
+		return `from A`
+	case RegionFromB:
+		return `from B`
+	case RegionConflict:
+		return `conflict`
+	case RegionResolved:
+		return `resolved`
+	default:
+		return `unknown`
+	}
+}
+
+// Lines gets the lines for the region which were taken by the merge.
+// For a conflict there are no lines taken so nil is returned.
+func (r *MergeRegion) Lines() []string {
+	switch r.Kind {
+	case RegionUnchanged, RegionFromA:
+		return r.A
+	case RegionFromB:
+		return r.B
+	case RegionResolved:
+		return r.Resolved
+	default:
+		return nil
+	}
+}
+
+// Err is the error from the diffs this merge was performed with, or nil if none.
+// A merge result with an error has no regions.
+func (m *MergeResult) Err() error {
+	return m.err
+}
+
+// HasConflicts determines if any of the regions are conflicts.
+func (m *MergeResult) HasConflicts() bool {
+	for _, region := range m.Regions {
+		if region.Kind == RegionConflict {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve uses the given resolver to resolve the conflict regions in this merge result.
+// The conflicts which the resolver leaves unresolved are kept as conflicts.
+// If the resolver is nil nothing is resolved. This merge result is returned for chaining.
+func (m *MergeResult) Resolve(resolver Resolver) *MergeResult {
+	if resolver == nil {
+		return m
+	}
+	for i := range m.Regions {
+		region := &m.Regions[i]
+		if region.Kind != RegionConflict {
+			continue
+		}
+		if lines, ok := resolver(*region); ok {
+			region.Kind = RegionResolved
+			region.Resolved = lines
+		}
+	}
+	return m
+}
+
+// Lines gets the merged lines where the conflicts are labelled
+// using the style and labels from the given options.
+// The base section is only written for a three-way merge.
+// If the options are nil the default options are used. The diff algorithm in the options is not used.
+func (m *MergeResult) Lines(options *MergeOptions) []string {
+	if options == nil {
+		options = &MergeOptions{}
+	}
+	result := []string{}
+	for _, region := range m.Regions {
+		if region.Kind == RegionConflict {
+			result = options.appendConflict(result, region, m.threeWay)
+		} else {
+			result = append(result, region.Lines()...)
+		}
+	}
+	return result
+}
@@ -0,0 +1,139 @@
+package godiff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_MergeResult_Regions3(t *testing.T) {
+	base := lines(`1`, `2`, `3`, `4`, `5`, `6`)
+	ours := lines(`one`, `2`, `3`, `four`, `5`, `6`)
+	theirs := lines(`1`, `2`, `three`, `FOUR`, `5`, `6`, `7`)
+	result := NewMerge3(base, ours, theirs, nil)
+	checkRegions(t, result,
+		`from A [1] [one] [1]`,
+		`unchanged [2] [2] [2]`,
+		`conflict [3 4] [3 four] [three FOUR]`,
+		`unchanged [5 6] [5 6] [5 6]`,
+		`from B [] [] [7]`)
+	boolEqual(t, result.HasConflicts(), true, `HasConflicts`)
+
+	result = NewMerge3(base, ours, base, nil)
+	boolEqual(t, result.HasConflicts(), false, `HasConflicts`)
+	checkSlices(t, result.Lines(nil), ours)
+}
+
+func Test_MergeResult_Regions2(t *testing.T) {
+	result := NewMerge(nil, lines(`1`, `2`, `3`), lines(`1`, `two`, `3`, `4`))
+	checkRegions(t, result,
+		`unchanged [] [1] [1]`,
+		`conflict [] [2] [two]`,
+		`unchanged [] [3] [3]`,
+		`conflict [] [] [4]`)
+	boolEqual(t, result.HasConflicts(), true, `HasConflicts`)
+
+	// The base section isn't written for a two-way merge.
+	checkSlices(t, result.Lines(&MergeOptions{Style: Diff3Style}),
+		lines(`1`,
+			`<<<<<<<`,
+			`2`,
+			`=======`,
+			`two`,
+			`>>>>>>>`,
+			`3`,
+			`<<<<<<<`,
+			`=======`,
+			`4`,
+			`>>>>>>>`))
+
+	result = NewMerge(nil, lines(`1`, `2`), lines(`1`, `2`))
+	boolEqual(t, result.HasConflicts(), false, `HasConflicts`)
+}
+
+func Test_MergeResult_MarkerContent(t *testing.T) {
+	// Lines which look like markers are still content in the regions.
+	base := lines(`a`, `=======`, `b`)
+	result := NewMerge3(base, lines(`a`, `=======`, `B`), base, nil)
+	boolEqual(t, result.HasConflicts(), false, `HasConflicts`)
+	checkRegions(t, result,
+		`unchanged [a =======] [a =======] [a =======]`,
+		`from A [b] [B] [b]`)
+}
+
+func Test_MergeResult_Resolve(t *testing.T) {
+	base := lines(`1`, `2`, `3`, `4`)
+	ours := lines(`one`, `2`, `3`, `four`)
+	theirs := lines(`1`, `2`, `three`, `FOUR`, `5`)
+	checkResolve(t, base, ours, theirs, ResolveOurs, lines(`one`, `2`, `3`, `four`))
+	checkResolve(t, base, ours, theirs, ResolveTheirs, lines(`one`, `2`, `three`, `FOUR`, `5`))
+	checkResolve(t, base, ours, theirs, ResolveUnion, lines(`one`, `2`, `3`, `four`, `three`, `FOUR`, `5`))
+
+	// A callback which only resolves some conflicts leaves the others labelled.
+	base = lines(`1`, `2`, `3`)
+	ours = lines(`one`, `2`, `three`)
+	theirs = lines(`ONE`, `2`, `THREE`)
+	resolver := func(region MergeRegion) ([]string, bool) {
+		if region.Base[0] == `1` {
+			return lines(`1!`), true
+		}
+		return nil, false
+	}
+	result := NewMerge3(base, ours, theirs, &MergeOptions{Resolve: resolver})
+	boolEqual(t, result.HasConflicts(), true, `HasConflicts`)
+	checkRegions(t, result,
+		`resolved [1] [one] [ONE]`,
+		`unchanged [2] [2] [2]`,
+		`conflict [3] [three] [THREE]`)
+	checkSlices(t, result.Lines(nil), lines(`1!`, `2`,
+		`<<<<<<<`,
+		`three`,
+		`=======`,
+		`THREE`,
+		`>>>>>>>`))
+}
+
+func Test_MergeResult_ResolveTwoWay(t *testing.T) {
+	a := lines(`1`, `2`, `3`)
+	b := lines(`1`, `two`, `3`, `4`)
+	checkSlices(t, NewMerge(nil, a, b).Resolve(ResolveOurs).Lines(nil), a)
+	checkSlices(t, NewMerge(nil, a, b).Resolve(ResolveTheirs).Lines(nil), b)
+	checkSlices(t, NewMerge(nil, a, b).Resolve(ResolveUnion).Lines(nil), lines(`1`, `2`, `two`, `3`, `4`))
+	boolEqual(t, NewMerge(nil, a, b).Resolve(ResolveUnion).HasConflicts(), false, `HasConflicts`)
+	boolEqual(t, NewMerge(nil, a, b).Resolve(nil).HasConflicts(), true, `HasConflicts`)
+}
+
+func Test_MergeResult_String(t *testing.T) {
+	strEqual(t, RegionUnchanged.String(), `unchanged`, `RegionUnchanged`)
+	strEqual(t, RegionFromA.String(), `from A`, `RegionFromA`)
+	strEqual(t, RegionFromB.String(), `from B`, `RegionFromB`)
+	strEqual(t, RegionConflict.String(), `conflict`, `RegionConflict`)
+	strEqual(t, RegionResolved.String(), `resolved`, `RegionResolved`)
+	strEqual(t, RegionKind(7).String(), `unknown`, `RegionKind(7)`)
+}
+
+// checkRegions checks the kind and lines of each region in the given merge result.
+func checkRegions(t *testing.T, result *MergeResult, exp ...string) {
+	parts := make([]string, len(result.Regions))
+	for i, region := range result.Regions {
+		parts[i] = fmt.Sprint(region.Kind, ` `, region.Base, ` `, region.A, ` `, region.B)
+	}
+	strEqual(t, strings.Join(parts, "\n"), strings.Join(exp, "\n"), `regions`)
+}
+
+// checkResolve checks the lines of a three-way merge using the given resolver.
+func checkResolve(t *testing.T, base, ours, theirs []string, resolver Resolver, exp []string) {
+	result := NewMerge3(base, ours, theirs, &MergeOptions{Resolve: resolver})
+	boolEqual(t, result.HasConflicts(), false, `HasConflicts`)
+	checkSlices(t, result.Lines(nil), exp)
+	checkSlices(t, Merge3Options(base, ours, theirs, &MergeOptions{Resolve: resolver}), exp)
+}
+
+func boolEqual(t *testing.T, value, exp bool, msg string) {
+	if value != exp {
+		t.Error("Unexpected boolean value:",
+			"\n   Message:  ", msg,
+			"\n   Value:    ", value,
+			"\n   Expected: ", exp)
+	}
+}
@@ -0,0 +1,148 @@
+package godiff
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Grant-Nelson/goDiff/comparable"
+	"github.com/Grant-Nelson/goDiff/step"
+)
+
+// stringValues is a comparable which can provide the string value for each of its entries.
+type stringValues interface {
+	comparable.Comparable
+	AValue(aIndex int) string
+	BValue(bIndex int) string
+}
+
+// NormalizeDiff creates an algorithm which compares entries after they have been normalized
+// with the given normalizer, such as comparable.IgnoreAllSpace or comparable.FoldCase,
+// then diffs them with the given algorithm. The normalizer is only used when the comparable is a
+// comparable.String, comparable.Runes, or comparable.RuneSlice, otherwise the comparable is diffed as is.
+// Since the entries themselves aren't changed, the formatters, such as PlusMinusCustom,
+// still output the original lines. If the algorithm is nil the default diff configuration is used.
+func NormalizeDiff(diff Algorithm, normalizer comparable.Normalizer) Algorithm {
+	if diff == nil {
+		diff = DefaultDiff()
+	}
+	return func(comp comparable.Comparable) Results {
+		if normalizer == nil {
+			return diff(comp)
+		}
+		ctx, inner := splitContext(comp)
+		switch inner := inner.(type) {
+		case *comparable.String:
+			comp = withContext(ctx, comparable.NewNormalized(inner, normalizer))
+		case *comparable.Runes:
+			comp = withContext(ctx, comparable.NewNormalizedRunes(inner, normalizer))
+		case *comparable.RuneSlice:
+			comp = withContext(ctx, comparable.NewNormalizedRuneSlice(inner, normalizer))
+		}
+		return diff(comp)
+	}
+}
+
+// InternDiff creates an algorithm which replaces each entry with an integer ID, using
+// comparable.NewInterned, then diffs the IDs with the given algorithm. This makes each comparison
+// an integer comparison, which is faster when the entries are expensive to compare, such as long lines.
+// The interning is only used when the comparable is hashable, otherwise the comparable is diffed as is.
+// Since the IDs don't have the values of the entries, this should be inside of any algorithm which needs
+// the values, e.g. IgnoreBlankLines(InternDiff(nil)). If the algorithm is nil the default diff configuration is used.
+func InternDiff(diff Algorithm) Algorithm {
+	if diff == nil {
+		diff = DefaultDiff()
+	}
+	return func(comp comparable.Comparable) Results {
+		ctx, inner := splitContext(comp)
+		if hash, ok := inner.(comparable.Hashable); ok {
+			comp = withContext(ctx, comparable.NewInterned(hash))
+		}
+		return diff(comp)
+	}
+}
+
+// IgnoreBlankLines creates an algorithm which diffs the lines which aren't blank with the given
+// algorithm, so that blank lines don't affect how the other lines are aligned, similar to the
+// `--ignore-blank-lines` option of GNU diff. A blank line is empty or only whitespace.
+// The blank lines are then added back, with the blank lines between the same aligned lines
+// being equal. Any extra blank lines are still reported as added or removed.
+// This is only used when the comparable can provide string values, such as comparable.String,
+// otherwise the comparable is diffed as is. If the algorithm is nil the default diff configuration is used.
+func IgnoreBlankLines(diff Algorithm) Algorithm {
+	if diff == nil {
+		diff = DefaultDiff()
+	}
+	return func(comp comparable.Comparable) Results {
+		ctx, inner := splitContext(comp)
+		values, ok := inner.(stringValues)
+		if !ok {
+			return diff(comp)
+		}
+
+		aIndices := nonBlank(values.ALength(), values.AValue)
+		bIndices := nonBlank(values.BLength(), values.BValue)
+		path := diff(withContext(ctx, newFiltered(values, aIndices, bIndices)))
+		if path.Err() != nil {
+			return path
+		}
+		return restoreBlankLines(path, values.ALength(), values.BLength(), aIndices, bIndices)
+	}
+}
+
+// nonBlank gets the indices of the values which are not blank.
+func nonBlank(length int, value func(index int) string) []int {
+	indices := []int{}
+	for i := 0; i < length; i++ {
+		if len(strings.TrimSpace(value(i))) > 0 {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// restoreBlankLines creates the results for the full comparable from the results of the diff of
+// the lines which aren't blank. The given indices are the indices of the lines which aren't blank.
+func restoreBlankLines(path Results, aLength, bLength int, aIndices, bIndices []int) Results {
+	runs := &pathRuns{}
+
+	// gap adds the steps for the lines between the last aligned lines and the given lines.
+	// The blank lines at the start of the gap on both sides are equal.
+	aPrev, bPrev := 0, 0
+	gap := func(aNext, bNext int) {
+		same := 0
+		for aPrev+same < aNext && bPrev+same < bNext && !isIndex(aIndices, aPrev+same) && !isIndex(bIndices, bPrev+same) {
+			same++
+		}
+		runs.add(step.Equal, same)
+		runs.add(step.Removed, aNext-aPrev-same)
+		runs.add(step.Added, bNext-bPrev-same)
+	}
+
+	aIndex, bIndex := 0, 0
+	path.Read(func(stepType step.Type, count int) {
+		switch stepType {
+		case step.Equal:
+			for i := 0; i < count; i++ {
+				aNext, bNext := aIndices[aIndex+i], bIndices[bIndex+i]
+				gap(aNext, bNext)
+				runs.add(step.Equal, 1)
+				aPrev, bPrev = aNext+1, bNext+1
+			}
+			aIndex += count
+			bIndex += count
+		case step.Added:
+			bIndex += count
+		case step.Removed:
+			aIndex += count
+		}
+	})
+	gap(aLength, bLength)
+
+	return runs.collect(path.Approximate())
+}
+
+// isIndex determines if the given index is in the given sorted indices.
+func isIndex(indices []int, index int) bool {
+	i := sort.SearchInts(indices, index)
+	return i < len(indices) && indices[i] == index
+}
@@ -0,0 +1,92 @@
+package godiff
+
+import (
+	"testing"
+
+	"github.com/Grant-Nelson/goDiff/comparable"
+	"github.com/Grant-Nelson/goDiff/internal/collector"
+)
+
+func Test_NormalizeDiff_Whitespace(t *testing.T) {
+	a := lines(`func a() {`, `	return  1`, `}`)
+	b := lines(`func a()  {`, `    return 1 `, `}`)
+	checkSlices(t, PlusMinusCustom(NormalizeDiff(nil, comparable.IgnoreAllSpace), a, b),
+		lines(` func a() {`, ` 	return  1`, ` }`))
+	checkSlices(t, PlusMinusCustom(NormalizeDiff(nil, comparable.IgnoreSpaceChange), a, b),
+		lines(` func a() {`, ` 	return  1`, ` }`))
+	checkSlices(t, PlusMinusCustom(NormalizeDiff(nil, comparable.IgnoreSpaceChange), lines(`a b`, `c`), lines(`ab`, `c  `)),
+		lines(`-a b`, `+ab`, ` c`))
+	checkSlices(t, PlusMinusCustom(NormalizeDiff(nil, comparable.IgnoreTrailingSpace), lines(`a`, `b  `), lines(`a `, `b`)),
+		lines(` a`, ` b  `))
+
+	// Without a normalizer the lines are compared as is.
+	checkSlices(t, PlusMinusCustom(NormalizeDiff(nil, nil), lines(`a`), lines(`a `)),
+		lines(`-a`, `+a `))
+}
+
+func Test_NormalizeDiff_Algorithms(t *testing.T) {
+	a := lines(`x`, `a b`, `y`, `z`)
+	b := lines(`x`, `a  b`, `z`)
+	for _, diff := range []Algorithm{HirschbergDiff(-1, true), WagnerDiff(-1), MyersDiff(-1), PatienceDiff(nil), HistogramDiff(-1, true, -1)} {
+		checkSlices(t, PlusMinusCustom(NormalizeDiff(diff, comparable.IgnoreSpaceChange), a, b),
+			lines(` x`, ` a b`, `-y`, ` z`))
+	}
+}
+
+func Test_IgnoreBlankLines(t *testing.T) {
+	a := lines(`a`, ``, `b`, `c`, ``, ``, `d`)
+	b := lines(`a`, `b`, ``, `c`, ` `, `d`, ``)
+	checkSlices(t, PlusMinusCustom(IgnoreBlankLines(nil), a, b),
+		lines(` a`, `-`, ` b`, `+`, ` c`, ` `, `-`, ` d`, `+`))
+
+	// Blank lines don't anchor the other lines.
+	a = lines(`x`, ``, `y`, ``, `z`)
+	b = lines(`z`, ``, `y`, ``, `x`)
+	checkSlices(t, PlusMinusCustom(IgnoreBlankLines(nil), a, b),
+		lines(`-x`, `-`, `+z`, `+`, ` y`, ` `, `-z`, `+x`))
+
+	// Combined with a normalizer.
+	checkSlices(t, PlusMinusCustom(NormalizeDiff(IgnoreBlankLines(nil), comparable.IgnoreAllSpace),
+		lines(`a b`, ``, `c`), lines(`ab`, `c`)),
+		lines(` a b`, `-`, ` c`))
+}
+
+func Test_NormalizeDiff_Unicode(t *testing.T) {
+	a := lines("Café", `Menu`, `tea`)
+	b := lines("café", `MENU`, `coffee`)
+	checkSlices(t, PlusMinusCustom(NormalizeDiff(nil, comparable.Canonical), a, b),
+		lines(`-Café`, `-Menu`, `-tea`, "+café", `+MENU`, `+coffee`))
+	checkSlices(t, PlusMinusCustom(NormalizeDiff(nil, comparable.FoldCase), a, b),
+		lines(`-Café`, "+café", ` Menu`, `-tea`, `+coffee`))
+	checkSlices(t, PlusMinusCustom(NormalizeDiff(nil, comparable.Chain(comparable.Canonical, comparable.FoldCase)), a, b),
+		lines(` Café`, ` Menu`, `-tea`, `+coffee`))
+}
+
+func Test_NormalizeDiff_Runes(t *testing.T) {
+	diff := NormalizeDiff(nil, comparable.FoldCase)
+	path := diff(comparable.NewRunes([]rune(`Hello`), []rune(`hELLO!`)))
+	checkPathString(t, path, `=5 +1`)
+	path = diff(comparable.NewRuneSlice([][]rune{[]rune(`A`), []rune(`b`)}, [][]rune{[]rune(`B`)}))
+	checkPathString(t, path, `-1 =1`)
+}
+
+// checkPathString checks the path of the given results.
+func checkPathString(t *testing.T, path Results, exp string) {
+	strEqual(t, path.(*collector.Collector).String(), exp, `path`)
+}
+
+func Test_InternDiff(t *testing.T) {
+	a := lines(`a`, `b`, `c`, `a`, `b`, `b`, `a`)
+	b := lines(`c`, `b`, `a`, `b`, `a`, `c`)
+	for _, diff := range []Algorithm{HirschbergDiff(-1, true), WagnerDiff(-1), MyersDiff(-1), PatienceDiff(nil), HistogramDiff(-1, true, -1)} {
+		checkSlices(t, PlusMinusCustom(InternDiff(diff), a, b), PlusMinusCustom(diff, a, b))
+	}
+
+	// Comparables which aren't hashable are diffed as is.
+	checkPathString(t, InternDiff(nil)(comparable.NewInterface(
+		[]interface{}{1, 2}, []interface{}{2}, nil)), `-1 =1`)
+
+	// Wrappers which need the values can be outside of the interning.
+	checkSlices(t, PlusMinusCustom(IgnoreBlankLines(InternDiff(nil)), lines(`a`, ``, `b`), lines(`a`, `b`)),
+		lines(` a`, `-`, ` b`))
+}
@@ -0,0 +1,634 @@
+package godiff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Grant-Nelson/goDiff/step"
+)
+
+type (
+	// ParseError is the error returned when diff text can not be parsed.
+	ParseError struct {
+
+		// Line is the line number, starting at one, of the line which could not be parsed.
+		Line int
+
+		// Column is the column, starting at one, in the line where the problem was found.
+		Column int
+
+		// Text is the text of the line which could not be parsed.
+		Text string
+
+		// Message describes the problem with the line.
+		Message string
+	}
+
+	// HunkLine is a single line in a hunk.
+	HunkLine struct {
+
+		// Type indicates if the line is context (Equal), Added, or Removed.
+		Type step.Type
+
+		// Text is the line without the prefix or newline.
+		Text string
+	}
+
+	// Hunk is a group of changes, with the context lines around them, from a parsed diff.
+	// The start lines begin at one. When a count is zero the start is the line before the hunk.
+	Hunk struct {
+
+		// AStart is the line in A which the hunk starts at.
+		AStart int
+
+		// ACount is the number of lines from A in the hunk.
+		ACount int
+
+		// BStart is the line in B which the hunk starts at.
+		BStart int
+
+		// BCount is the number of lines from B in the hunk.
+		BCount int
+
+		// Lines are the lines in the hunk in order.
+		Lines []HunkLine
+
+		// ANoNewline indicates the hunk reaches the end of A and A doesn't end with a newline.
+		ANoNewline bool
+
+		// BNoNewline indicates the hunk reaches the end of B and B doesn't end with a newline.
+		BNoNewline bool
+	}
+
+	// FileDiff is the diff of a single file from a parsed diff.
+	FileDiff struct {
+
+		// AName is the name of the original file without any timestamp.
+		AName string
+
+		// BName is the name of the changed file without any timestamp.
+		BName string
+
+		// Hunks are the groups of changes in order.
+		Hunks []*Hunk
+	}
+
+	// diffParser is the state for parsing diff text.
+	diffParser struct {
+		lines []string
+		index int
+
+		// hunk is the index of the first line of the hunk being parsed.
+		hunk int
+	}
+)
+
+// Error gets the message for this error with the position of the problem.
+func (err *ParseError) Error() string {
+	return fmt.Sprintf(`line %d, column %d: %s: %q`, err.Line, err.Column, err.Message, err.Text)
+}
+
+// Read will read the runs of the step types in this hunk.
+func (h *Hunk) Read(hndl step.PathCallback) {
+	if hndl == nil {
+		return
+	}
+	for i := 0; i < len(h.Lines); {
+		stepType, count := h.Lines[i].Type, 1
+		for i+count < len(h.Lines) && h.Lines[i+count].Type == stepType {
+			count++
+		}
+		hndl(stepType, count)
+		i += count
+	}
+}
+
+// aFirst gets the index of the first line of A in this hunk.
+func (h *Hunk) aFirst() int {
+	if h.ACount > 0 {
+		return h.AStart - 1
+	}
+	return h.AStart
+}
+
+// bFirst gets the index of the first line of B in this hunk.
+func (h *Hunk) bFirst() int {
+	if h.BCount > 0 {
+		return h.BStart - 1
+	}
+	return h.BStart
+}
+
+// Patch creates a patch from the hunks in this file diff, which can be applied to,
+// or reverted from, the lines of the file split with strings.Split(text, "\n").
+// The lines between the hunks are equal and the lines after the last hunk are kept.
+func (f *FileDiff) Patch() *Patch {
+	p := &Patch{partial: true}
+	aIndex := 0
+	aNoNewline, bNoNewline := false, false
+	for _, h := range f.Hunks {
+		p.push(step.Equal, h.aFirst()-aIndex, nil)
+		aIndex = h.aFirst() + h.ACount
+		aNoNewline, bNoNewline = h.ANoNewline, h.BNoNewline
+		for _, line := range h.Lines {
+			var lines []string
+			if line.Type != step.Equal {
+				lines = []string{line.Text}
+			}
+			p.push(line.Type, 1, lines)
+		}
+	}
+
+	// When only one side ends with a newline, that side has an empty last line.
+	if aNoNewline && !bNoNewline {
+		p.push(step.Added, 1, []string{``})
+	} else if bNoNewline && !aNoNewline {
+		p.push(step.Removed, 1, []string{``})
+	}
+
+	return p
+}
+
+// ParseDiff parses unified diff text, as created by `diff -u` and `git diff`, or context diff
+// text, as created by `diff -c`, into the diffs for each file. Any text which isn't part of a
+// file diff, such as email text or git's extended headers, is skipped. If the text can not be
+// parsed a *ParseError is returned with the position of the problem.
+func ParseDiff(text string) ([]*FileDiff, error) {
+	parser := &diffParser{
+		lines: strings.Split(text, "\n"),
+	}
+	if count := len(parser.lines); count > 0 && parser.lines[count-1] == `` {
+		parser.lines = parser.lines[:count-1]
+	}
+
+	files := []*FileDiff{}
+	for parser.hasMore() {
+		line := parser.peek()
+		switch {
+		case strings.HasPrefix(line, `--- `) && strings.HasPrefix(parser.peekAt(1), `+++ `):
+			file, err := parser.parseUnifiedFile()
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+
+		case strings.HasPrefix(line, `*** `) && strings.HasPrefix(parser.peekAt(1), `--- `):
+			file, err := parser.parseContextFile()
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+
+		case strings.HasPrefix(line, `@@ `) || strings.HasPrefix(line, `***************`):
+			return nil, parser.errorf(1, `hunk found before the file headers`)
+
+		default:
+			parser.index++
+		}
+	}
+	return files, nil
+}
+
+// hasMore indicates there are more lines to parse.
+func (p *diffParser) hasMore() bool {
+	return p.index < len(p.lines)
+}
+
+// peek gets the current line.
+func (p *diffParser) peek() string {
+	return p.peekAt(0)
+}
+
+// peekAt gets the line at the given offset from the current line,
+// or an empty string if there is no line there.
+func (p *diffParser) peekAt(offset int) string {
+	if index := p.index + offset; index < len(p.lines) {
+		return p.lines[index]
+	}
+	return ``
+}
+
+// next gets the current line and moves to the next line.
+func (p *diffParser) next() string {
+	line := p.peek()
+	p.index++
+	return line
+}
+
+// errorf creates a parse error for the current line at the given column.
+func (p *diffParser) errorf(column int, format string, args ...interface{}) error {
+	return &ParseError{
+		Line:    p.index + 1,
+		Column:  column,
+		Text:    p.peek(),
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// fileName gets the file name from a file header line after the given prefix.
+// Any timestamp after a tab is removed.
+func fileName(line, prefix string) string {
+	name := strings.TrimPrefix(line, prefix)
+	if tab := strings.IndexByte(name, '\t'); tab >= 0 {
+		name = name[:tab]
+	}
+	return strings.TrimRight(name, "\r")
+}
+
+// parseUnifiedFile parses the headers and hunks of a file in a unified diff.
+func (p *diffParser) parseUnifiedFile() (*FileDiff, error) {
+	file := &FileDiff{
+		AName: fileName(p.next(), `--- `),
+		BName: fileName(p.next(), `+++ `),
+	}
+	for p.hasMore() && strings.HasPrefix(p.peek(), `@@ `) {
+		h, err := p.parseUnifiedHunk()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.addHunk(file, h); err != nil {
+			return nil, err
+		}
+	}
+	return file, nil
+}
+
+// parseUnifiedHunk parses a hunk, starting with its header, in a unified diff.
+func (p *diffParser) parseUnifiedHunk() (*Hunk, error) {
+	p.hunk = p.index
+	header := strings.TrimRight(p.peek(), "\r")
+	end := strings.Index(header[3:], ` @@`)
+	if end < 0 {
+		return nil, p.errorf(len(header)+1, `missing the end of the hunk header`)
+	}
+	ranges := strings.Split(header[3:3+end], ` `)
+	if len(ranges) != 2 || !strings.HasPrefix(ranges[0], `-`) || !strings.HasPrefix(ranges[1], `+`) {
+		return nil, p.errorf(4, `the hunk header must have an A and B range`)
+	}
+
+	h := &Hunk{}
+	var err error
+	if h.AStart, h.ACount, err = p.parseUnifiedRange(ranges[0][1:], 5); err != nil {
+		return nil, err
+	}
+	if h.BStart, h.BCount, err = p.parseUnifiedRange(ranges[1][1:], 6+len(ranges[0])); err != nil {
+		return nil, err
+	}
+	p.index++
+
+	aLeft, bLeft := h.ACount, h.BCount
+	for aLeft > 0 || bLeft > 0 {
+		if !p.hasMore() {
+			return nil, p.errorf(1, `the hunk ended early, expected %d more A lines and %d more B lines`, aLeft, bLeft)
+		}
+
+		line := p.peek()
+		stepType := step.Equal
+		switch {
+		case line == ``:
+			// Some tools remove the trailing space from empty context lines.
+			line = ` `
+		case line[0] == ' ':
+		case line[0] == '-':
+			stepType = step.Removed
+		case line[0] == '+':
+			stepType = step.Added
+		case line[0] == '\\':
+			p.markNoNewline(h, h.Lines, true, true)
+			p.index++
+			continue
+		default:
+			return nil, p.errorf(1, `expected a hunk line starting with ' ', '-', or '+', expected %d more A lines and %d more B lines`, aLeft, bLeft)
+		}
+
+		if stepType != step.Added {
+			if aLeft <= 0 {
+				return nil, p.errorf(1, `more A lines than the hunk header's count of %d`, h.ACount)
+			}
+			aLeft--
+		}
+		if stepType != step.Removed {
+			if bLeft <= 0 {
+				return nil, p.errorf(1, `more B lines than the hunk header's count of %d`, h.BCount)
+			}
+			bLeft--
+		}
+		h.Lines = append(h.Lines, HunkLine{Type: stepType, Text: line[1:]})
+		p.index++
+	}
+
+	if p.hasMore() && strings.HasPrefix(p.peek(), `\`) {
+		p.markNoNewline(h, h.Lines, true, true)
+		p.index++
+	}
+	return h, nil
+}
+
+// parseUnifiedRange parses a range of a unified hunk header, "start,count" or "start".
+// The column is the column of the range in the header, used for errors.
+func (p *diffParser) parseUnifiedRange(text string, column int) (int, int, error) {
+	startText, countText := text, `1`
+	countColumn := column
+	if comma := strings.IndexByte(text, ','); comma >= 0 {
+		startText, countText = text[:comma], text[comma+1:]
+		countColumn += comma + 1
+	}
+	start, err := strconv.Atoi(startText)
+	if err != nil || start < 0 {
+		return 0, 0, p.errorf(column, `invalid range start %q`, startText)
+	}
+	count, err := strconv.Atoi(countText)
+	if err != nil || count < 0 {
+		return 0, 0, p.errorf(countColumn, `invalid range count %q`, countText)
+	}
+	if count > 0 && start == 0 {
+		return 0, 0, p.errorf(column, `the range start must be at least one when the count isn't zero`)
+	}
+	return start, count, nil
+}
+
+// markNoNewline marks the side, or sides, of the last of the given lines as not ending with a newline.
+// The A side is only marked if allowA is true and the B side only if allowB is true.
+func (p *diffParser) markNoNewline(h *Hunk, lines []HunkLine, allowA, allowB bool) {
+	if len(lines) <= 0 {
+		return
+	}
+	switch lines[len(lines)-1].Type {
+	case step.Equal:
+		h.ANoNewline = h.ANoNewline || allowA
+		h.BNoNewline = h.BNoNewline || allowB
+	case step.Removed:
+		h.ANoNewline = h.ANoNewline || allowA
+	case step.Added:
+		h.BNoNewline = h.BNoNewline || allowB
+	}
+}
+
+// addHunk adds the given hunk to the file after checking that it follows the previous hunk.
+// The first line of the most recently parsed hunk is used for errors.
+func (p *diffParser) addHunk(file *FileDiff, h *Hunk) error {
+	if count := len(file.Hunks); count > 0 {
+		prev := file.Hunks[count-1]
+		aGap := h.aFirst() - (prev.aFirst() + prev.ACount)
+		bGap := h.bFirst() - (prev.bFirst() + prev.BCount)
+		if aGap < 0 || bGap < 0 {
+			return p.lineError(p.hunk, `the hunk overlaps or is before the previous hunk`)
+		}
+		if aGap != bGap {
+			return p.lineError(p.hunk, `the lines between the hunk and the previous hunk differ between A and B`)
+		}
+	} else if h.aFirst()-h.bFirst() != 0 {
+		return p.lineError(p.hunk, `the lines before the first hunk differ between A and B`)
+	}
+	file.Hunks = append(file.Hunks, h)
+	return nil
+}
+
+// contextLine is a line from one of the sections of a hunk in a context diff.
+type contextLine struct {
+	prefix byte
+	text   string
+}
+
+// parseContextFile parses the headers and hunks of a file in a context diff.
+func (p *diffParser) parseContextFile() (*FileDiff, error) {
+	file := &FileDiff{
+		AName: fileName(p.next(), `*** `),
+		BName: fileName(p.next(), `--- `),
+	}
+	for p.hasMore() && strings.HasPrefix(p.peek(), `***************`) {
+		h, err := p.parseContextHunk()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.addHunk(file, h); err != nil {
+			return nil, err
+		}
+	}
+	return file, nil
+}
+
+// parseContextHunk parses a hunk, starting with the line of stars, in a context diff.
+func (p *diffParser) parseContextHunk() (*Hunk, error) {
+	p.hunk = p.index
+	p.index++
+	if !p.hasMore() {
+		return nil, p.errorf(1, `missing the A range of the hunk`)
+	}
+
+	aHeader := p.index
+	aStart, aEnd, aHasEnd, err := p.parseContextRange(`*** `, ` ****`)
+	if err != nil {
+		return nil, err
+	}
+	aLines, aNoNewline, err := p.parseContextSection(`- `, func(line string) bool {
+		return strings.HasPrefix(line, `--- `)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.hasMore() {
+		return nil, p.errorf(1, `missing the B range of the hunk`)
+	}
+	bHeader := p.index
+	bStart, bEnd, bHasEnd, err := p.parseContextRange(`--- `, ` ----`)
+	if err != nil {
+		return nil, err
+	}
+	bLines, bNoNewline, err := p.parseContextSection(`+ `, func(line string) bool {
+		return strings.HasPrefix(line, `***************`) ||
+			(strings.HasPrefix(line, `*** `) && strings.HasPrefix(p.peekAt(1), `--- `)) ||
+			(strings.HasPrefix(line, `--- `) && strings.HasPrefix(p.peekAt(1), `+++ `))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Hunk{}
+	switch {
+	case len(aLines) <= 0:
+		err = p.contextSide(bHeader, bLines, '+', step.Added, h)
+		h.ANoNewline, h.BNoNewline = bNoNewline && contextEndsEqual(bLines), bNoNewline
+	case len(bLines) <= 0:
+		err = p.contextSide(aHeader, aLines, '-', step.Removed, h)
+		h.ANoNewline, h.BNoNewline = aNoNewline, aNoNewline && contextEndsEqual(aLines)
+	default:
+		err = p.contextMerge(aHeader, aLines, bLines, h)
+		h.ANoNewline, h.BNoNewline = aNoNewline, bNoNewline
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range h.Lines {
+		if line.Type != step.Added {
+			h.ACount++
+		}
+		if line.Type != step.Removed {
+			h.BCount++
+		}
+	}
+	if h.AStart, err = p.contextCount(aHeader, aStart, aEnd, aHasEnd, h.ACount, `A`); err != nil {
+		return nil, err
+	}
+	if h.BStart, err = p.contextCount(bHeader, bStart, bEnd, bHasEnd, h.BCount, `B`); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// parseContextRange parses the range line of a section of a context diff hunk,
+// "*** start,end ****" or "*** start ****", with the given prefix and suffix.
+func (p *diffParser) parseContextRange(prefix, suffix string) (int, int, bool, error) {
+	line := strings.TrimRight(p.peek(), "\r")
+	if !strings.HasPrefix(line, prefix) || !strings.HasSuffix(line, suffix) || len(line) < len(prefix)+len(suffix) {
+		return 0, 0, false, p.errorf(1, `expected a range line like "%sstart,end%s"`, prefix, suffix)
+	}
+
+	text := line[len(prefix) : len(line)-len(suffix)]
+	column := len(prefix) + 1
+	startText, endText := text, ``
+	comma := strings.IndexByte(text, ',')
+	if comma >= 0 {
+		startText, endText = text[:comma], text[comma+1:]
+	}
+	start, err := strconv.Atoi(startText)
+	if err != nil || start < 0 {
+		return 0, 0, false, p.errorf(column, `invalid range start %q`, startText)
+	}
+	if comma < 0 {
+		p.index++
+		return start, start, false, nil
+	}
+	end, err := strconv.Atoi(endText)
+	if err != nil || end < start {
+		return 0, 0, false, p.errorf(column+comma+1, `invalid range end %q`, endText)
+	}
+	p.index++
+	return start, end, true, nil
+}
+
+// parseContextSection parses the lines of a section of a context diff hunk until the
+// given end check returns true or there are no more lines. The given prefix is the
+// prefix for changed lines which are only in this section.
+// The lines are returned with an indication that the section ended with a no newline marker.
+func (p *diffParser) parseContextSection(changed string, isEnd func(line string) bool) ([]contextLine, bool, error) {
+	lines := []contextLine{}
+	noNewline := false
+	for p.hasMore() && !isEnd(p.peek()) {
+		line := p.peek()
+		switch {
+		case strings.HasPrefix(line, `\`):
+			if len(lines) <= 0 || noNewline {
+				return nil, false, p.errorf(1, `unexpected no newline marker`)
+			}
+			noNewline = true
+			p.index++
+			continue
+		case noNewline:
+			return nil, false, p.errorf(1, `unexpected line after a no newline marker`)
+		case line == ``:
+			// Some tools remove the trailing spaces from empty context lines.
+			line = `  `
+		case strings.TrimRight(line, " ") == changed[:1] || line == `!`:
+			line += ` `
+		}
+
+		if len(line) < 2 || line[1] != ' ' ||
+			(line[0] != ' ' && line[0] != '!' && line[0] != changed[0]) {
+			if len(lines) > 0 {
+				// The lines following the hunk aren't part of the diff.
+				break
+			}
+			return nil, false, p.errorf(1, `expected a hunk line starting with "  ", "%s", or "! "`, changed)
+		}
+		lines = append(lines, contextLine{prefix: line[0], text: line[2:]})
+		p.index++
+	}
+	return lines, noNewline, nil
+}
+
+// contextEndsEqual determines if the last of the given lines is a context line.
+func contextEndsEqual(lines []contextLine) bool {
+	return len(lines) > 0 && lines[len(lines)-1].prefix == ' '
+}
+
+// contextSide adds the lines of the only section of a context diff hunk to the hunk.
+// The other side of the hunk only has the context lines from this section.
+func (p *diffParser) contextSide(header int, lines []contextLine, changed byte, changeType step.Type, h *Hunk) error {
+	for _, line := range lines {
+		switch line.prefix {
+		case ' ':
+			h.Lines = append(h.Lines, HunkLine{Type: step.Equal, Text: line.text})
+		case changed:
+			h.Lines = append(h.Lines, HunkLine{Type: changeType, Text: line.text})
+		default:
+			return p.lineError(header, `a changed line, "! ", requires both the A and B sections`)
+		}
+	}
+	return nil
+}
+
+// contextMerge merges the A and B sections of a context diff hunk into the hunk's lines.
+func (p *diffParser) contextMerge(header int, aLines, bLines []contextLine, h *Hunk) error {
+	i, j := 0, 0
+	for i < len(aLines) || j < len(bLines) {
+		switch {
+		case i < len(aLines) && aLines[i].prefix == '-':
+			h.Lines = append(h.Lines, HunkLine{Type: step.Removed, Text: aLines[i].text})
+			i++
+
+		case j < len(bLines) && bLines[j].prefix == '+':
+			h.Lines = append(h.Lines, HunkLine{Type: step.Added, Text: bLines[j].text})
+			j++
+
+		case (i < len(aLines) && aLines[i].prefix == '!') || (j < len(bLines) && bLines[j].prefix == '!'):
+			if i >= len(aLines) || aLines[i].prefix != '!' || j >= len(bLines) || bLines[j].prefix != '!' {
+				return p.lineError(header, `the changed lines, "! ", in the A and B sections don't line up`)
+			}
+			for ; i < len(aLines) && aLines[i].prefix == '!'; i++ {
+				h.Lines = append(h.Lines, HunkLine{Type: step.Removed, Text: aLines[i].text})
+			}
+			for ; j < len(bLines) && bLines[j].prefix == '!'; j++ {
+				h.Lines = append(h.Lines, HunkLine{Type: step.Added, Text: bLines[j].text})
+			}
+
+		case i < len(aLines) && j < len(bLines):
+			h.Lines = append(h.Lines, HunkLine{Type: step.Equal, Text: aLines[i].text})
+			i++
+			j++
+
+		default:
+			return p.lineError(header, `the context lines in the A and B sections don't line up`)
+		}
+	}
+	return nil
+}
+
+// contextCount checks the number of lines in a section of a context diff hunk against the range
+// from the section's header line. The start of the range, using unified diff convention, is returned.
+func (p *diffParser) contextCount(header, start, end int, hasEnd bool, count int, side string) (int, error) {
+	if hasEnd {
+		if exp := end - start + 1; exp != count {
+			return 0, p.lineError(header, fmt.Sprintf(`the %s range has %d lines but the hunk has %d %s lines`, side, exp, count, side))
+		}
+	} else if count > 1 {
+		return 0, p.lineError(header, fmt.Sprintf(`the %s range has one line but the hunk has %d %s lines`, side, count, side))
+	}
+	if count > 0 && start == 0 {
+		return 0, p.lineError(header, `the range start must be at least one when there are lines`)
+	}
+	return start, nil
+}
+
+// lineError creates a parse error for the line at the given index.
+func (p *diffParser) lineError(index int, message string) error {
+	return &ParseError{
+		Line:    index + 1,
+		Column:  1,
+		Text:    p.lines[index],
+		Message: message,
+	}
+}
@@ -0,0 +1,240 @@
+package godiff
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Grant-Nelson/goDiff/step"
+)
+
+func Test_ParseDiff_Unified(t *testing.T) {
+	text := "From: someone\n" +
+		"Subject: a change\n" +
+		"\n" +
+		"diff --git a/file b/file\n" +
+		"index 1234567..89abcde 100644\n" +
+		"--- a/file\t2024-01-01 00:00:00\n" +
+		"+++ b/file\t2024-01-02 00:00:00\n" +
+		"@@ -1,4 +1,4 @@ func header\n" +
+		" 1\n" +
+		"-2\n" +
+		"+two\n" +
+		" 3\n" +
+		"\n" +
+		"@@ -10,0 +11,2 @@\n" +
+		"+new 1\n" +
+		"+new 2\n" +
+		"-- \n" +
+		"signature\n"
+	files, err := ParseDiff(text)
+	checkNoErr(t, err)
+	intEqual(t, len(files), 1, `file count`)
+	strEqual(t, files[0].AName, `a/file`, `AName`)
+	strEqual(t, files[0].BName, `b/file`, `BName`)
+	intEqual(t, len(files[0].Hunks), 2, `hunk count`)
+	checkHunk(t, files[0].Hunks[0], 1, 4, 1, 4, `=1 -1 +1 =2`)
+	checkHunk(t, files[0].Hunks[1], 10, 0, 11, 2, `+2`)
+	checkParsedPath(t, files[0].Patch(), `=1 -1 +1 =8 +2`)
+}
+
+func Test_ParseDiff_Context(t *testing.T) {
+	text := "*** a\t2024-01-01 00:00:00\n" +
+		"--- b\t2024-01-02 00:00:00\n" +
+		"***************\n" +
+		"*** 1,4 ****\n" +
+		"  1\n" +
+		"! 2\n" +
+		"! 3\n" +
+		"  4\n" +
+		"--- 1,4 ----\n" +
+		"  1\n" +
+		"! two\n" +
+		"! three\n" +
+		"  4\n" +
+		"***************\n" +
+		"*** 8,9 ****\n" +
+		"--- 8,10 ----\n" +
+		"  8\n" +
+		"+ 8.5\n" +
+		"  9\n" +
+		"***************\n" +
+		"*** 12 ****\n" +
+		"- 12\n" +
+		"--- 12 ----\n"
+	files, err := ParseDiff(text)
+	checkNoErr(t, err)
+	intEqual(t, len(files), 1, `file count`)
+	strEqual(t, files[0].AName, `a`, `AName`)
+	strEqual(t, files[0].BName, `b`, `BName`)
+	intEqual(t, len(files[0].Hunks), 3, `hunk count`)
+	checkHunk(t, files[0].Hunks[0], 1, 4, 1, 4, `=1 -2 +2 =1`)
+	checkHunk(t, files[0].Hunks[1], 8, 2, 8, 3, `=1 +1 =1`)
+	checkHunk(t, files[0].Hunks[2], 12, 1, 12, 0, `-1`)
+
+	a := strings.Split("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n", "\n")
+	b := strings.Split("1\ntwo\nthree\n4\n5\n6\n7\n8\n8.5\n9\n10\n11\n13\n", "\n")
+	result, err := files[0].Patch().Apply(a)
+	checkNoErr(t, err)
+	strEqual(t, strings.Join(result, "\n"), strings.Join(b, "\n"), `Apply`)
+}
+
+func Test_ParseDiff_NoNewline(t *testing.T) {
+	checkParseApply(t,
+		"--- a\n"+
+			"+++ b\n"+
+			"@@ -1,2 +1,2 @@\n"+
+			" 1\n"+
+			"-2\n"+
+			"\\ No newline at end of file\n"+
+			"+two\n",
+		"1\n2", "1\ntwo\n")
+	checkParseApply(t,
+		"*** a\n"+
+			"--- b\n"+
+			"***************\n"+
+			"*** 1,2 ****\n"+
+			"  1\n"+
+			"! 2\n"+
+			"--- 1,2 ----\n"+
+			"  1\n"+
+			"! two\n"+
+			"\\ No newline at end of file\n",
+		"1\n2\n", "1\ntwo")
+	checkParseApply(t,
+		"*** a\n"+
+			"--- b\n"+
+			"***************\n"+
+			"*** 0 ****\n"+
+			"--- 1,2 ----\n"+
+			"+ 1\n"+
+			"+ 2\n"+
+			"\\ No newline at end of file\n",
+		"", "1\n2")
+}
+
+func Test_ParseDiff_RoundTrip(t *testing.T) {
+	texts := []string{
+		"", "1\n", "1", "1\n2\n3\n", "1\n2\n3", "1\n3\n", "2\n3\n4\n5\n", "a\nb\n\nc\n",
+		"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n", "1\n2\nthree\n4\n5\n6\n7\n8\nnine\n10",
+	}
+	for _, a := range texts {
+		for _, b := range texts {
+			for contextLines := 0; contextLines <= 3; contextLines++ {
+				checkParseApply(t, Unified(`a`, `b`, strings.Split(a, "\n"), strings.Split(b, "\n"), contextLines), a, b)
+			}
+		}
+	}
+}
+
+func Test_ParseDiff_MultipleFiles(t *testing.T) {
+	text := "--- a/one\n" +
+		"+++ b/one\n" +
+		"@@ -1 +1 @@\n" +
+		"-1\n" +
+		"+one\n" +
+		"*** a/two\n" +
+		"--- b/two\n" +
+		"***************\n" +
+		"*** 1 ****\n" +
+		"! 2\n" +
+		"--- 1 ----\n" +
+		"! two\n" +
+		"--- a/three\n" +
+		"+++ b/three\n" +
+		"@@ -1 +0,0 @@\n" +
+		"-3\n"
+	files, err := ParseDiff(text)
+	checkNoErr(t, err)
+	intEqual(t, len(files), 3, `file count`)
+	names := []string{}
+	for _, file := range files {
+		names = append(names, file.AName+`:`+file.BName)
+	}
+	strEqual(t, strings.Join(names, ` `), `a/one:b/one a/two:b/two a/three:b/three`, `names`)
+	checkParsedPath(t, files[2].Patch(), `-1`)
+}
+
+func Test_ParseDiff_Errors(t *testing.T) {
+	checkParseErr(t, "@@ -1 +1 @@\n-1\n+2\n",
+		`line 1, column 1: hunk found before the file headers: "@@ -1 +1 @@"`)
+	checkParseErr(t, "--- a\n+++ b\n@@ -1 +1\n-1\n+2\n",
+		`line 3, column 9: missing the end of the hunk header: "@@ -1 +1"`)
+	checkParseErr(t, "--- a\n+++ b\n@@ -x,2 +1,2 @@\n",
+		`line 3, column 5: invalid range start "x": "@@ -x,2 +1,2 @@"`)
+	checkParseErr(t, "--- a\n+++ b\n@@ -1,2 +1,y @@\n",
+		`line 3, column 12: invalid range count "y": "@@ -1,2 +1,y @@"`)
+	checkParseErr(t, "--- a\n+++ b\n@@ -1,2 +1,2 @@\n 1\n*2\n",
+		`line 5, column 1: expected a hunk line starting with ' ', '-', or '+', expected 1 more A lines and 1 more B lines: "*2"`)
+	checkParseErr(t, "--- a\n+++ b\n@@ -1,2 +1,2 @@\n 1\n-2\n",
+		`line 6, column 1: the hunk ended early, expected 0 more A lines and 1 more B lines: ""`)
+	checkParseErr(t, "--- a\n+++ b\n@@ -1,2 +1 @@\n 1\n+2\n",
+		`line 5, column 1: more B lines than the hunk header's count of 1: "+2"`)
+	checkParseErr(t, "--- a\n+++ b\n@@ -5 +5 @@\n-5\n+five\n@@ -3 +3 @@\n-3\n+three\n",
+		`line 6, column 1: the hunk overlaps or is before the previous hunk: "@@ -3 +3 @@"`)
+	checkParseErr(t, "--- a\n+++ b\n@@ -5 +6 @@\n-5\n+five\n",
+		`line 3, column 1: the lines before the first hunk differ between A and B: "@@ -5 +6 @@"`)
+	checkParseErr(t, "*** a\n--- b\n***************\n*** 1,x ****\n",
+		`line 4, column 7: invalid range end "x": "*** 1,x ****"`)
+	checkParseErr(t, "*** a\n--- b\n***************\n*** 1,2\n",
+		`line 4, column 1: expected a range line like "*** start,end ****": "*** 1,2"`)
+	checkParseErr(t, "*** a\n--- b\n***************\n*** 1,2 ****\n  1\n! 2\n",
+		`line 7, column 1: missing the B range of the hunk: ""`)
+	checkParseErr(t, "*** a\n--- b\n***************\n*** 1,2 ****\n  1\n! 2\n--- 1,2 ----\n",
+		`line 4, column 1: a changed line, "! ", requires both the A and B sections: "*** 1,2 ****"`)
+	checkParseErr(t, "*** a\n--- b\n***************\n*** 1,3 ****\n  1\n! 2\n--- 1,2 ----\n  1\n! two\n",
+		`line 4, column 1: the A range has 3 lines but the hunk has 2 A lines: "*** 1,3 ****"`)
+	checkParseErr(t, "*** a\n--- b\n***************\n*** 1,2 ****\n  1\n- 2\n\\ No newline at end of file\n  3\n",
+		`line 8, column 1: unexpected line after a no newline marker: "  3"`)
+}
+
+// checkHunk checks the ranges and step runs of the given hunk.
+func checkHunk(t *testing.T, h *Hunk, aStart, aCount, bStart, bCount int, exp string) {
+	result := fmt.Sprintf(`-%d,%d +%d,%d`, h.AStart, h.ACount, h.BStart, h.BCount)
+	strEqual(t, result, fmt.Sprintf(`-%d,%d +%d,%d`, aStart, aCount, bStart, bCount), `hunk range`)
+	checkParsedPath(t, h, exp)
+}
+
+// checkParsedPath checks the step runs read from the given hunk or patch.
+func checkParsedPath(t *testing.T, path interface{ Read(step.PathCallback) }, exp string) {
+	parts := []string{}
+	path.Read(func(stepType step.Type, count int) {
+		parts = append(parts, fmt.Sprintf(`%s%d`, stepType, count))
+	})
+	strEqual(t, strings.Join(parts, ` `), exp, `path`)
+}
+
+// checkParseApply checks that the parsed diff turns A into B and B back into A.
+func checkParseApply(t *testing.T, text, a, b string) {
+	files, err := ParseDiff(text)
+	checkNoErr(t, err)
+	if a == b {
+		intEqual(t, len(files), 0, `file count`)
+		return
+	}
+	if len(files) != 1 {
+		t.Error("Expected one file diff but got ", len(files), " from:\n", text)
+		return
+	}
+
+	p := files[0].Patch()
+	result, err := p.Apply(strings.Split(a, "\n"))
+	checkNoErr(t, err)
+	strEqual(t, strings.Join(result, "\n"), b, `Apply `+text)
+
+	result, err = p.Revert(strings.Split(b, "\n"))
+	checkNoErr(t, err)
+	strEqual(t, strings.Join(result, "\n"), a, `Revert `+text)
+}
+
+// checkParseErr checks that parsing the given text fails with the expected error.
+func checkParseErr(t *testing.T, text, exp string) {
+	_, err := ParseDiff(text)
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Error("Expected a parse error but got: ", err)
+		return
+	}
+	strEqual(t, err.Error(), exp, `parse error`)
+}
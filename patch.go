@@ -0,0 +1,195 @@
+package godiff
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Grant-Nelson/goDiff/comparable"
+	"github.com/Grant-Nelson/goDiff/step"
+)
+
+// ErrPatchMismatch is the error returned when a patch can not be applied
+// or reverted because the input doesn't match the lines in the patch.
+var ErrPatchMismatch = errors.New(`patch does not match the input`)
+
+// check that the patch can be used as the resulting diff.
+var _ Results = (*Patch)(nil)
+
+type (
+	// patchStep is a continuous group of a step type in a patch.
+	patchStep struct {
+
+		// stepType is the type for this group.
+		stepType step.Type
+
+		// count is the number of lines in the group.
+		count int
+
+		// lines are the added lines for an Added group or the removed lines
+		// for a Removed group. Equal groups don't keep their lines.
+		lines []string
+	}
+
+	// Patch is a diff which keeps the lines which were added and removed so that
+	// it can be applied to A to reconstruct B, or reverted from B to reconstruct A.
+	// The equal lines are not kept so only the changes need to be stored.
+	// The patch is also the Results of the diff it was created from.
+	Patch struct {
+		steps       []patchStep
+		total       int
+		approximate bool
+		err         error
+
+		// partial indicates the patch may end before the end of the input, so any lines
+		// after the end of the patch are kept. This is true for a patch from a parsed diff
+		// since the hunks only cover the lines up to the last change.
+		partial bool
+	}
+)
+
+// DiffPatch performs a diff on the given A and B lines and creates a patch from it.
+// This will use the default diff configuration to perform the diff.
+func DiffPatch(a, b []string) *Patch {
+	return NewPatch(Diff(comparable.NewString(a, b)), a, b)
+}
+
+// NewPatch creates a new patch from the given results of the diff of the given A and B lines.
+// The added and removed lines are copied out of A and B into the patch.
+// If the results have an error, the patch will have the same error.
+func NewPatch(path Results, a, b []string) *Patch {
+	p := &Patch{
+		steps:       make([]patchStep, 0, path.Count()),
+		approximate: path.Approximate(),
+		err:         path.Err(),
+	}
+
+	aIndex, bIndex := 0, 0
+	path.Read(func(stepType step.Type, count int) {
+		var lines []string
+		switch stepType {
+		case step.Equal:
+			aIndex += count
+			bIndex += count
+		case step.Added:
+			lines = append(lines, b[bIndex:bIndex+count]...)
+			bIndex += count
+		case step.Removed:
+			lines = append(lines, a[aIndex:aIndex+count]...)
+			aIndex += count
+		}
+		p.push(stepType, count, lines)
+	})
+	return p
+}
+
+// push adds a new group to the end of the patch.
+// If the last group has the same type, the new group is joined into it.
+func (p *Patch) push(stepType step.Type, count int, lines []string) {
+	if count <= 0 {
+		return
+	}
+	if last := len(p.steps) - 1; last >= 0 && p.steps[last].stepType == stepType {
+		p.steps[last].count += count
+		p.steps[last].lines = append(p.steps[last].lines, lines...)
+		p.total += count
+		return
+	}
+	p.steps = append(p.steps, patchStep{
+		stepType: stepType,
+		count:    count,
+		lines:    lines,
+	})
+	p.total += count
+}
+
+// Count is the number of steps in this patch.
+func (p *Patch) Count() int {
+	return len(p.steps)
+}
+
+// Total is the total number of lines represented by this patch.
+// The total sum of all the counts in each step.
+func (p *Patch) Total() int {
+	return p.total
+}
+
+// Read will read the steps to take for this patch.
+func (p *Patch) Read(hndl step.PathCallback) {
+	if hndl != nil {
+		for _, s := range p.steps {
+			hndl(s.stepType, s.count)
+		}
+	}
+}
+
+// Approximate indicates if the diff this patch was created from was approximate.
+func (p *Patch) Approximate() bool {
+	return p.approximate
+}
+
+// Err is the error from the diff this patch was created from, or nil if none.
+// A patch with an error can not be applied or reverted.
+func (p *Patch) Err() error {
+	return p.err
+}
+
+// Apply applies this patch to the given A lines to reconstruct the B lines.
+// If the removed lines in the patch don't match the given lines then an error is returned.
+// A patch created from a diff covers all of A so the given lines must have the same length as A,
+// while a patch from a parsed diff keeps any lines after the end of the patch unchanged.
+func (p *Patch) Apply(a []string) ([]string, error) {
+	return p.replay(a, step.Added, step.Removed)
+}
+
+// Revert reverts this patch from the given B lines to reconstruct the A lines.
+// If the added lines in the patch don't match the given lines then an error is returned.
+// A patch created from a diff covers all of B so the given lines must have the same length as B,
+// while a patch from a parsed diff keeps any lines after the end of the patch unchanged.
+func (p *Patch) Revert(b []string) ([]string, error) {
+	return p.replay(b, step.Removed, step.Added)
+}
+
+// replay walks the patch over the given input. The lines in the groups with the insert type
+// are inserted into the result and the lines in the groups with the skip type are checked
+// against the input and skipped. Equal lines are copied from the input.
+// Unless the patch is partial, there must be no input left after the end of the patch.
+func (p *Patch) replay(input []string, insertType, skipType step.Type) ([]string, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	result := make([]string, 0, len(input))
+	index := 0
+	for _, s := range p.steps {
+		switch s.stepType {
+		case step.Equal:
+			if index+s.count > len(input) {
+				return nil, fmt.Errorf(`%w: expected %d equal lines at line %d but there are only %d lines`,
+					ErrPatchMismatch, s.count, index+1, len(input))
+			}
+			result = append(result, input[index:index+s.count]...)
+			index += s.count
+
+		case insertType:
+			result = append(result, s.lines...)
+
+		case skipType:
+			for i, line := range s.lines {
+				if index+i >= len(input) {
+					return nil, fmt.Errorf(`%w: expected %q at line %d but there are only %d lines`,
+						ErrPatchMismatch, line, index+i+1, len(input))
+				}
+				if input[index+i] != line {
+					return nil, fmt.Errorf(`%w: expected %q at line %d but got %q`,
+						ErrPatchMismatch, line, index+i+1, input[index+i])
+				}
+			}
+			index += s.count
+		}
+	}
+	if !p.partial && index < len(input) {
+		return nil, fmt.Errorf(`%w: expected %d lines but there are %d lines`,
+			ErrPatchMismatch, index, len(input))
+	}
+	return append(result, input[index:]...), nil
+}
@@ -0,0 +1,120 @@
+package godiff
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Grant-Nelson/goDiff/comparable"
+)
+
+func Test_Patch_ApplyRevert(t *testing.T) {
+	checkPatch(t, exampleA, exampleB)
+	checkPatch(t, exampleB, exampleA)
+	checkPatch(t, frobnitzA, frobnitzB)
+	checkPatch(t, lines(), lines(`a`, `b`))
+	checkPatch(t, lines(`a`, `b`), lines())
+	checkPatch(t, lines(`a`, `b`, `c`), lines(`a`, `b`, `c`))
+}
+
+func Test_Patch_Results(t *testing.T) {
+	a := lines(`one`, `two`, `three`, `four`)
+	b := lines(`one`, `2`, `three`, `four`, `five`)
+	path := Diff(comparable.NewString(a, b))
+	p := NewPatch(path, a, b)
+	intEqual(t, p.Count(), path.Count(), `Patch.Count`)
+	intEqual(t, p.Total(), path.Total(), `Patch.Total`)
+	strEqual(t, strings.Join(PlusMinusCustom(func(comparable.Comparable) Results { return p }, a, b), `|`),
+		` one|-two|+2| three| four|+five`, `PlusMinus of Patch`)
+}
+
+func Test_Patch_Remainder(t *testing.T) {
+	// A patch from a diff covers the whole input so extra lines are a mismatch.
+	p := DiffPatch(lines(`a`, `b`), lines(`a`, `c`))
+	checkPatchErr(t, p, lines(`a`, `b`, `d`, `e`), true,
+		`patch does not match the input: expected 2 lines but there are 4 lines`)
+	checkPatchErr(t, p, lines(`a`, `c`, `d`, `e`), false,
+		`patch does not match the input: expected 2 lines but there are 4 lines`)
+
+	// A patch from a parsed diff only covers the lines up to the last hunk.
+	files, err := ParseDiff(Unified(`a`, `b`, lines(`a`, `b`), lines(`a`, `c`), 1))
+	checkNoErr(t, err)
+	p = files[0].Patch()
+	result, err := p.Apply(lines(`a`, `b`, `d`, `e`))
+	checkNoErr(t, err)
+	strEqual(t, strings.Join(result, `|`), `a|c|d|e`, `Apply with remainder`)
+
+	result, err = p.Revert(lines(`a`, `c`, `d`, `e`))
+	checkNoErr(t, err)
+	strEqual(t, strings.Join(result, `|`), `a|b|d|e`, `Revert with remainder`)
+}
+
+func Test_Patch_Mismatch(t *testing.T) {
+	p := DiffPatch(lines(`a`, `b`, `c`), lines(`a`, `x`, `c`))
+	checkPatchErr(t, p, lines(`a`, `y`, `c`), true,
+		`patch does not match the input: expected "b" at line 2 but got "y"`)
+	checkPatchErr(t, p, lines(`a`), true,
+		`patch does not match the input: expected "b" at line 2 but there are only 1 lines`)
+	checkPatchErr(t, p, lines(), true,
+		`patch does not match the input: expected 1 equal lines at line 1 but there are only 0 lines`)
+	checkPatchErr(t, p, lines(`a`, `b`, `c`), false,
+		`patch does not match the input: expected "x" at line 2 but got "b"`)
+
+	failed := NewPatch(Diff(nil), nil, nil)
+	checkPatchErr(t, failed, lines(`a`), true,
+		`diff failed: runtime error: invalid memory address or nil pointer dereference`)
+}
+
+// checkPatch checks that a patch of the given lines can be applied and reverted.
+func checkPatch(t *testing.T, a, b []string) {
+	p := DiffPatch(a, b)
+	result, err := p.Apply(a)
+	checkNoErr(t, err)
+	strEqual(t, strings.Join(result, "\n"), strings.Join(b, "\n"), `Apply`)
+
+	result, err = p.Revert(b)
+	checkNoErr(t, err)
+	strEqual(t, strings.Join(result, "\n"), strings.Join(a, "\n"), `Revert`)
+}
+
+// checkPatchErr checks that applying or reverting the patch on the given lines fails.
+func checkPatchErr(t *testing.T, p *Patch, input []string, apply bool, exp string) {
+	var err error
+	if apply {
+		_, err = p.Apply(input)
+	} else {
+		_, err = p.Revert(input)
+	}
+	if err == nil || err.Error() != exp {
+		t.Error("Unexpected patch error:",
+			"\n   Expected: ", exp,
+			"\n   Result:   ", err)
+	}
+	if p.Err() == nil && !errors.Is(err, ErrPatchMismatch) {
+		t.Error("Expected a patch mismatch error: ", err)
+	}
+}
+
+func checkNoErr(t *testing.T, err error) {
+	if err != nil {
+		t.Error("Unexpected error: ", err)
+	}
+}
+
+func intEqual(t *testing.T, value, exp int, msg string) {
+	if value != exp {
+		t.Error("Unexpected integer value:",
+			"\n   Message:  ", msg,
+			"\n   Value:    ", value,
+			"\n   Expected: ", exp)
+	}
+}
+
+func strEqual(t *testing.T, value, exp string, msg string) {
+	if value != exp {
+		t.Error("Unexpected string value:",
+			"\n   Message:  ", msg,
+			"\n   Value:    ", value,
+			"\n   Expected: ", exp)
+	}
+}
@@ -17,11 +17,22 @@ func PlusMinus(a, b []string) []string {
 // It formats the results by prepending a "+" to new strings in [b],
 // a "-" for any to removed strings from [a], and " " if the strings are the same.
 // This was can use any given diff algorithm.
+// If the diff fails the result is empty, use PlusMinusCustomErr to get the error.
 func PlusMinusCustom(diff Algorithm, a, b []string) []string {
+	result, _ := PlusMinusCustomErr(diff, a, b)
+	return result
+}
+
+// PlusMinusCustomErr gets the labelled difference between the two slices
+// the same as PlusMinusCustom but returns the error from Results.Err if the diff failed.
+func PlusMinusCustomErr(diff Algorithm, a, b []string) ([]string, error) {
 	if diff == nil {
 		diff = DefaultDiff()
 	}
 	path := diff(comparable.NewString(a, b))
+	if err := path.Err(); err != nil {
+		return nil, err
+	}
 
 	result := make([]string, 0, path.Total())
 	aIndex, bIndex := 0, 0
@@ -45,5 +56,5 @@ func PlusMinusCustom(diff Algorithm, a, b []string) []string {
 			}
 		}
 	})
-	return result
+	return result, nil
 }
@@ -0,0 +1,32 @@
+package godiff
+
+import (
+	"sync"
+
+	"github.com/Grant-Nelson/goDiff/comparable"
+)
+
+// PooledDiff creates an algorithm which is safe to use from multiple goroutines at the same time,
+// such as from the handlers of an HTTP server. An instance of an algorithm is not safe to use
+// from multiple goroutines because its buffers, like the Hirschberg score vectors and
+// the Wagner-Fischer matrix, are shared by each diff performed with it.
+//
+// Each diff takes an instance from a pool of instances and puts it back once the diff is done,
+// so the instances' already allocated buffers are reused without being shared. The given
+// constructor is used to create new instances when the pool is empty, such as HybridDiff with
+// the wanted configuration. If the constructor is nil then DefaultDiff is used.
+func PooledDiff(newAlgorithm func() Algorithm) Algorithm {
+	if newAlgorithm == nil {
+		newAlgorithm = DefaultDiff
+	}
+	pool := &sync.Pool{
+		New: func() interface{} {
+			return newAlgorithm()
+		},
+	}
+	return func(comp comparable.Comparable) Results {
+		alg := pool.Get().(Algorithm)
+		defer pool.Put(alg)
+		return alg(comp)
+	}
+}
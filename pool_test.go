@@ -0,0 +1,72 @@
+package godiff
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Grant-Nelson/goDiff/comparable"
+	"github.com/Grant-Nelson/goDiff/internal/collector"
+)
+
+func Test_PooledDiff_Basics(t *testing.T) {
+	diff := PooledDiff(nil)
+	checkAlg(t, diff, "A", "A", "=1")
+	checkAlg(t, diff, "A", "B", "-1 +1")
+	checkAlg(t, diff, "kitten", "sitting", "-1 +1 =3 -1 +1 =1 +1")
+	checkSlices(t, PlusMinusCustom(diff, exampleA, exampleB), hirschbergPlusMinus)
+
+	diff = PooledDiff(func() Algorithm { return WagnerDiff(-1) })
+	checkAlg(t, diff, "saturday", "sunday", "=1 -2 =1 -1 +1 =3")
+	checkSlices(t, PlusMinusCustom(diff, exampleA, exampleB), wagnerPlusMinus)
+}
+
+func Test_PooledDiff_Concurrent(t *testing.T) {
+	comps := []comparable.Comparable{
+		comparable.NewString(exampleA, exampleB),
+		comparable.NewString(strings.Split(billNyeA, ` `), strings.Split(billNyeB, ` `)),
+		comparable.NewChar(billNyeA, billNyeB),
+		comparable.NewChar(`kitten`, `sitting`),
+	}
+	for _, newAlgorithm := range []func() Algorithm{
+		DefaultDiff,
+		func() Algorithm { return HirschbergDiff(-1, true) },
+		func() Algorithm { return ParallelHybridDiff(-1, true, DefaultWagnerThreshold, 2) },
+	} {
+		exps := make([]string, len(comps))
+		for i, comp := range comps {
+			exps[i] = newAlgorithm()(comp).(*collector.Collector).String()
+		}
+
+		diff := PooledDiff(newAlgorithm)
+		wg := sync.WaitGroup{}
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for n := 0; n < 20; n++ {
+					i := (g + n) % len(comps)
+					result := diff(comps[i]).(*collector.Collector).String()
+					if result != exps[i] {
+						t.Error("Pooled diff returned unexpected result:",
+							"\n   Expected: ", exps[i],
+							"\n   Result:   ", result)
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+	}
+}
+
+func Test_PooledDiff_Context(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	path, err := PooledDiff(nil).WithContext()(ctx, comparable.NewChar(`kitten`, `sitting`))
+	if err != context.Canceled {
+		t.Error("Unexpected error from a canceled diff: ", err)
+	}
+	checkContextPath(t, path, `-6 +7`, true)
+}
@@ -0,0 +1,198 @@
+package godiff
+
+import (
+	"strings"
+
+	"github.com/Grant-Nelson/goDiff/comparable"
+	"github.com/Grant-Nelson/goDiff/step"
+	"github.com/Grant-Nelson/goDiff/tokenize"
+)
+
+type (
+	// Refinement is the granularity used to find the changes inside of changed lines.
+	Refinement int
+
+	// Span is a range of bytes in a line, from the start up to but not including the end.
+	Span struct {
+
+		// Start is the byte offset of the first byte in the span.
+		Start int
+
+		// End is the byte offset after the last byte in the span.
+		End int
+	}
+
+	// RefinedLine is a line from a line diff with the spans of the line which changed.
+	RefinedLine struct {
+
+		// Type indicates if the line is Equal, Added, or Removed.
+		Type step.Type
+
+		// Text is the line's text.
+		Text string
+
+		// Spans are the parts of the text which were added or removed, in order.
+		// Equal lines have no spans. Added or removed lines, which couldn't be paired
+		// with lines from the other side, have one span for the whole line.
+		Spans []Span
+	}
+)
+
+const (
+	// RefineWords finds the changes by words, runs of whitespace, and single punctuation runes.
+	// This uses the tokenize.WordsAndSpace tokenizer.
+	RefineWords Refinement = iota
+
+	// RefineRunes finds the changes by each rune.
+	// This uses the tokenize.Runes tokenizer.
+	RefineRunes
+)
+
+// Refine performs a line diff then, for each block of removed lines followed by added lines,
+// diffs the block again by the given refinement to find the spans inside of the lines which changed.
+// This will use the default diff configuration to perform the diffs.
+func Refine(a, b []string, refinement Refinement) []RefinedLine {
+	return RefineCustom(nil, a, b, refinement)
+}
+
+// RefineCustom performs a line diff then, for each block of removed lines followed by added lines,
+// diffs the block again by the given refinement to find the spans inside of the lines which changed.
+// This can use any given diff algorithm, which is used for both the line diff and the refinement.
+// If any of the diffs fail the result is empty, use RefineCustomErr to get the error.
+func RefineCustom(diff Algorithm, a, b []string, refinement Refinement) []RefinedLine {
+	result, _ := RefineCustomErr(diff, a, b, refinement)
+	return result
+}
+
+// RefineCustomErr performs a line diff then refines the changed blocks the same as RefineCustom
+// but returns the error from Results.Err if any of the diffs failed.
+func RefineCustomErr(diff Algorithm, a, b []string, refinement Refinement) ([]RefinedLine, error) {
+	if diff == nil {
+		diff = DefaultDiff()
+	}
+	path := diff(comparable.NewString(a, b))
+	if err := path.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]RefinedLine, 0, path.Total())
+	aIndex, bIndex := 0, 0
+	removed, added := []string{}, []string{}
+	var err error
+	flush := func() {
+		if err == nil {
+			result, err = refineBlock(result, diff, removed, added, refinement)
+		}
+		removed, added = removed[:0], added[:0]
+	}
+
+	path.Read(func(stepType step.Type, count int) {
+		switch stepType {
+		case step.Equal:
+			flush()
+			for i := count - 1; i >= 0; i-- {
+				result = append(result, RefinedLine{Type: step.Equal, Text: a[aIndex]})
+				aIndex++
+				bIndex++
+			}
+		case step.Added:
+			added = append(added, b[bIndex:bIndex+count]...)
+			bIndex += count
+		case step.Removed:
+			if len(added) > 0 {
+				flush()
+			}
+			removed = append(removed, a[aIndex:aIndex+count]...)
+			aIndex += count
+		}
+	})
+	flush()
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// refineBlock appends the refined lines for a block of removed lines followed by added lines.
+// If only one side has lines then those lines have a span for the whole line.
+// If the diff of the block fails the error from Results.Err is returned.
+func refineBlock(result []RefinedLine, diff Algorithm, removed, added []string, refinement Refinement) ([]RefinedLine, error) {
+	if len(removed) <= 0 || len(added) <= 0 {
+		for _, line := range removed {
+			result = append(result, wholeLine(step.Removed, line))
+		}
+		for _, line := range added {
+			result = append(result, wholeLine(step.Added, line))
+		}
+		return result, nil
+	}
+
+	// Join the lines so that changes which move text between lines are found.
+	aText, bText := strings.Join(removed, "\n"), strings.Join(added, "\n")
+	tokenizer := tokenize.WordsAndSpace
+	if refinement == RefineRunes {
+		tokenizer = tokenize.Runes
+	}
+	aTokens, aOffsets := tokenizer(aText)
+	bTokens, bOffsets := tokenizer(bText)
+	path := diff(comparable.NewString(aTokens, bTokens))
+	if err := path.Err(); err != nil {
+		return nil, err
+	}
+
+	aSpans, bSpans := []Span{}, []Span{}
+	aIndex, bIndex := 0, 0
+	path.Read(func(stepType step.Type, count int) {
+		switch stepType {
+		case step.Equal:
+			aIndex += count
+			bIndex += count
+		case step.Added:
+			bSpans = appendSpan(bSpans, tokenSpan(bTokens, bOffsets, bIndex, count))
+			bIndex += count
+		case step.Removed:
+			aSpans = appendSpan(aSpans, tokenSpan(aTokens, aOffsets, aIndex, count))
+			aIndex += count
+		}
+	})
+
+	result = splitSpans(result, step.Removed, removed, aSpans)
+	return splitSpans(result, step.Added, added, bSpans), nil
+}
+
+// wholeLine creates a refined line where the whole line has changed.
+func wholeLine(stepType step.Type, line string) RefinedLine {
+	refined := RefinedLine{Type: stepType, Text: line}
+	if len(line) > 0 {
+		refined.Spans = []Span{{Start: 0, End: len(line)}}
+	}
+	return refined
+}
+
+// appendSpan appends the given span, joining it to the last span if they touch.
+func appendSpan(spans []Span, span Span) []Span {
+	if last := len(spans) - 1; last >= 0 && spans[last].End >= span.Start {
+		spans[last].End = maxInt(spans[last].End, span.End)
+		return spans
+	}
+	return append(spans, span)
+}
+
+// splitSpans appends the given lines, which were joined with newlines, with the spans
+// from the joined text split into the spans for each line.
+func splitSpans(result []RefinedLine, stepType step.Type, lines []string, spans []Span) []RefinedLine {
+	lineStart := 0
+	for _, line := range lines {
+		lineEnd := lineStart + len(line)
+		refined := RefinedLine{Type: stepType, Text: line}
+		for _, span := range spans {
+			start, end := maxInt(span.Start, lineStart), minInt(span.End, lineEnd)
+			if start < end {
+				refined.Spans = append(refined.Spans, Span{Start: start - lineStart, End: end - lineStart})
+			}
+		}
+		result = append(result, refined)
+		lineStart = lineEnd + 1
+	}
+	return result
+}
@@ -0,0 +1,80 @@
+package godiff
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_Refine_Words(t *testing.T) {
+	checkRefine(t, RefineWords,
+		lines(`same`, `The quick brown fox.`, `same`),
+		lines(`same`, `The slow brown fox!`, `same`),
+		` same`,
+		`-The [quick] brown fox[.]`,
+		`+The [slow] brown fox[!]`,
+		` same`)
+
+	checkRefine(t, RefineWords,
+		lines(`x := a + b`),
+		lines(`x  := a + c`),
+		`-x[ ]:= a + [b]`,
+		`+x[  ]:= a + [c]`)
+}
+
+func Test_Refine_Runes(t *testing.T) {
+	checkRefine(t, RefineRunes,
+		lines(`recieve`, `same`),
+		lines(`receive`, `same`),
+		`-rec[i]eve`,
+		`+rece[i]ve`,
+		` same`)
+
+	// Spans are byte offsets so multi-byte runes are kept whole.
+	checkRefine(t, RefineRunes,
+		lines(`naïve`),
+		lines(`naive`),
+		`-na[ï]ve`,
+		`+na[i]ve`)
+}
+
+func Test_Refine_Blocks(t *testing.T) {
+	// Lines which can't be paired are changed as a whole.
+	checkRefine(t, RefineWords,
+		lines(`a`, `b`),
+		lines(`a`, `b`, `new line`),
+		` a`,
+		` b`,
+		`+[new line]`)
+	checkRefine(t, RefineWords,
+		lines(`a`, `old line`, `b`),
+		lines(`a`, `b`),
+		` a`,
+		`-[old line]`,
+		` b`)
+
+	// Blocks with different numbers of lines are refined together.
+	checkRefine(t, RefineWords,
+		lines(`one two`, `three four`),
+		lines(`one two three four`),
+		`-one two`,
+		`-three four`,
+		`+one two[ ]three four`)
+}
+
+// checkRefine checks the refined lines, where the spans are written in square brackets.
+func checkRefine(t *testing.T, refinement Refinement, a, b []string, exp ...string) {
+	parts := []string{}
+	for _, line := range Refine(a, b, refinement) {
+		buf := &strings.Builder{}
+		buf.WriteString(strings.Replace(line.Type.String(), `=`, ` `, 1))
+		index := 0
+		for _, span := range line.Spans {
+			buf.WriteString(line.Text[index:span.Start])
+			buf.WriteString(`[` + line.Text[span.Start:span.End] + `]`)
+			index = span.End
+		}
+		buf.WriteString(line.Text[index:])
+		parts = append(parts, buf.String())
+	}
+	strEqual(t, strings.Join(parts, "\n"), strings.Join(exp, "\n"), `Refine`)
+}
@@ -0,0 +1,19 @@
+package godiff
+
+// The comparable package is imported with a different name in this file
+// so that the builtin comparable constraint can still be used.
+import comparables "github.com/Grant-Nelson/goDiff/comparable"
+
+// DiffSlices will perform a diff on the two given slices of any comparable type.
+// The entries are compared with the default equal (==).
+// This will use a new instance of the default diff configuration.
+func DiffSlices[T comparable](a, b []T) Results {
+	return Diff(comparables.NewSlice(a, b))
+}
+
+// DiffSlicesFunc will perform a diff on the two given slices of any type
+// where the entries are compared with the given equal function.
+// This will use a new instance of the default diff configuration.
+func DiffSlicesFunc[T any](a, b []T, equals func(a, b T) bool) Results {
+	return Diff(comparables.NewSliceFunc(a, b, equals))
+}
@@ -0,0 +1,47 @@
+package godiff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Grant-Nelson/goDiff/internal/collector"
+)
+
+type person struct {
+	name string
+	age  int
+}
+
+func Test_DiffSlices(t *testing.T) {
+	checkPath(t, DiffSlices([]int{1, 2, 3, 4, 5}, []int{1, 3, 4, 6, 5}), `=1 -1 =2 +1 =1`)
+	checkPath(t, DiffSlices(strings.Split(`kitten`, ``), strings.Split(`sitting`, ``)),
+		`-1 +1 =3 -1 +1 =1 +1`)
+	checkPath(t, DiffSlices(
+		[]person{{`Ann`, 31}, {`Bob`, 42}, {`Cat`, 27}},
+		[]person{{`Ann`, 31}, {`Bob`, 43}, {`Cat`, 27}, {`Dan`, 50}}),
+		`=1 -1 +1 =1 +1`)
+	checkPath(t, DiffSlices([]float64{}, []float64{1.5}), `+1`)
+}
+
+func Test_DiffSlicesFunc(t *testing.T) {
+	sameName := func(a, b person) bool { return a.name == b.name }
+	checkPath(t, DiffSlicesFunc(
+		[]person{{`Ann`, 31}, {`Bob`, 42}, {`Cat`, 27}},
+		[]person{{`Ann`, 31}, {`Bob`, 43}, {`Cat`, 27}, {`Dan`, 50}}, sameName),
+		`=3 +1`)
+
+	path := DiffSlicesFunc([]person{{`Ann`, 31}}, []person{{`Bob`, 42}}, nil)
+	if path.Err() == nil {
+		t.Error("Expected an error from a nil equal function.")
+	}
+}
+
+// checkPath checks the path from a diff.
+func checkPath(t *testing.T, path Results, exp string) {
+	if result := path.(*collector.Collector).String(); exp != result || path.Err() != nil {
+		t.Error("Diff returned unexpected result:",
+			"\n   Expected: ", exp,
+			"\n   Result:   ", result,
+			"\n   Error:    ", path.Err())
+	}
+}
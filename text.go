@@ -0,0 +1,99 @@
+package godiff
+
+import (
+	"github.com/Grant-Nelson/goDiff/comparable"
+	"github.com/Grant-Nelson/goDiff/step"
+	"github.com/Grant-Nelson/goDiff/tokenize"
+)
+
+// TextStep is a run of tokens from a text diff with the byte ranges of the run in A and B.
+// An Added run has an empty range in A at where it was inserted and
+// a Removed run has an empty range in B at where it was removed from.
+type TextStep struct {
+
+	// Type indicates if the run is Equal, Added, or Removed.
+	Type step.Type
+
+	// Count is the number of tokens in the run.
+	Count int
+
+	// A is the byte range of the run in A.
+	A Span
+
+	// B is the byte range of the run in B.
+	B Span
+}
+
+// DiffText splits the two texts into tokens with the given tokenizer, diffs the tokens,
+// then maps the results back to the byte ranges in the texts.
+// If the tokenizer is nil tokenize.WordsAndSpace is used.
+// This will use the default diff configuration to perform the diff.
+func DiffText(a, b string, tokenizer tokenize.Tokenizer) []TextStep {
+	return DiffTextCustom(nil, a, b, tokenizer)
+}
+
+// DiffTextCustom splits the two texts into tokens with the given tokenizer, diffs the tokens,
+// then maps the results back to the byte ranges in the texts.
+// If the tokenizer is nil tokenize.WordsAndSpace is used.
+// This can use any given diff algorithm.
+// If the diff fails the result is empty, use DiffTextCustomErr to get the error.
+func DiffTextCustom(diff Algorithm, a, b string, tokenizer tokenize.Tokenizer) []TextStep {
+	result, _ := DiffTextCustomErr(diff, a, b, tokenizer)
+	return result
+}
+
+// DiffTextCustomErr diffs the tokens of the two texts the same as DiffTextCustom
+// but returns the error from Results.Err if the diff failed.
+func DiffTextCustomErr(diff Algorithm, a, b string, tokenizer tokenize.Tokenizer) ([]TextStep, error) {
+	if diff == nil {
+		diff = DefaultDiff()
+	}
+	if tokenizer == nil {
+		tokenizer = tokenize.WordsAndSpace
+	}
+	aTokens, aOffsets := tokenizer(a)
+	bTokens, bOffsets := tokenizer(b)
+	path := diff(comparable.NewString(aTokens, bTokens))
+	if err := path.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]TextStep, 0, path.Count())
+	aIndex, bIndex := 0, 0
+	path.Read(func(stepType step.Type, count int) {
+		textStep := TextStep{
+			Type:  stepType,
+			Count: count,
+			A:     insertSpan(aOffsets, aIndex, len(a)),
+			B:     insertSpan(bOffsets, bIndex, len(b)),
+		}
+		if stepType != step.Added {
+			textStep.A = tokenSpan(aTokens, aOffsets, aIndex, count)
+			aIndex += count
+		}
+		if stepType != step.Removed {
+			textStep.B = tokenSpan(bTokens, bOffsets, bIndex, count)
+			bIndex += count
+		}
+		result = append(result, textStep)
+	})
+	return result, nil
+}
+
+// tokenSpan gets the byte range of the given number of tokens starting at the given index.
+func tokenSpan(tokens []string, offsets []int, index, count int) Span {
+	last := index + count - 1
+	return Span{
+		Start: offsets[index],
+		End:   offsets[last] + len(tokens[last]),
+	}
+}
+
+// insertSpan gets the empty byte range at the start of the token at the given index,
+// or at the end of the text if there are no more tokens.
+func insertSpan(offsets []int, index, length int) Span {
+	if index < len(offsets) {
+		return Span{Start: offsets[index], End: offsets[index]}
+	}
+	return Span{Start: length, End: length}
+}
@@ -0,0 +1,55 @@
+package godiff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Grant-Nelson/goDiff/tokenize"
+)
+
+func Test_DiffText_WordsAndSpace(t *testing.T) {
+	checkDiffText(t, `The quick brown fox`, `The slow brown fox jumps`, nil,
+		`=2 [The ] [The ]`,
+		`-1 [quick] [|slow brown fox jumps]`,
+		`+1 [| brown fox] [slow]`,
+		`=4 [ brown fox] [ brown fox]`,
+		`+2 [|] [ jumps]`)
+}
+
+func Test_DiffText_Words(t *testing.T) {
+	// The skipped whitespace is inside of the ranges for runs of more than one token.
+	checkDiffText(t, `one  two three`, `one two four`, tokenize.Words,
+		`=2 [one  two] [one two]`,
+		`-1 [three] [|four]`,
+		`+1 [|] [four]`)
+	checkDiffText(t, `one three`, `one two three`, tokenize.Words,
+		`=1 [one] [one]`,
+		`+1 [|three] [two]`,
+		`=1 [three] [three]`)
+}
+
+func Test_DiffText_Empty(t *testing.T) {
+	checkDiffText(t, ``, ``, nil)
+	checkDiffText(t, ``, `new text`, tokenize.Words,
+		`+2 [|] [new text]`)
+	checkDiffText(t, `old`, ``, tokenize.Code,
+		`-1 [old] [|]`)
+}
+
+// checkDiffText checks the text diff where the ranges are written as the text in the range in brackets.
+// An empty range is written as the text after it with a leading bar.
+func checkDiffText(t *testing.T, a, b string, tokenizer tokenize.Tokenizer, exp ...string) {
+	textRange := func(text string, span Span) string {
+		if span.Start == span.End {
+			return `[|` + text[span.Start:] + `]`
+		}
+		return `[` + text[span.Start:span.End] + `]`
+	}
+	parts := []string{}
+	for _, textStep := range DiffText(a, b, tokenizer) {
+		parts = append(parts, fmt.Sprintf(`%s%d %s %s`, textStep.Type, textStep.Count,
+			textRange(a, textStep.A), textRange(b, textStep.B)))
+	}
+	strEqual(t, strings.Join(parts, "\n"), strings.Join(exp, "\n"), `DiffText`)
+}
@@ -0,0 +1,144 @@
+package tokenize
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tokenizer splits the given text into tokens.
+// The tokens are returned with the byte offset in the text where each token starts.
+// The tokens are in order and don't overlap but may skip parts of the text, such as whitespace.
+type Tokenizer func(text string) (tokens []string, offsets []int)
+
+// Runes splits the text into each rune.
+func Runes(text string) ([]string, []int) {
+	tokens, offsets := []string{}, []int{}
+	for start := 0; start < len(text); {
+		_, size := utf8.DecodeRuneInString(text[start:])
+		tokens = append(tokens, text[start:start+size])
+		offsets = append(offsets, start)
+		start += size
+	}
+	return tokens, offsets
+}
+
+// Words splits the text into the runs of non-whitespace runes.
+// The whitespace is skipped.
+func Words(text string) ([]string, []int) {
+	return scan(text, func(r rune) (func(r rune) bool, bool) {
+		if unicode.IsSpace(r) {
+			return nil, false
+		}
+		return isNotSpace, true
+	})
+}
+
+// WordsAndSpace splits the text into words, runs of whitespace, and single punctuation runes.
+// Words are runs of letters, digits, and underscores. Newlines are always their own token
+// so that the tokens don't cross lines. No part of the text is skipped.
+func WordsAndSpace(text string) ([]string, []int) {
+	return scan(text, func(r rune) (func(r rune) bool, bool) {
+		switch {
+		case isWord(r):
+			return isWord, true
+		case isSpace(r):
+			return isSpace, true
+		default:
+			return nil, true
+		}
+	})
+}
+
+// Code splits the text into identifiers, numbers, and single punctuation runes.
+// Identifiers start with a letter or underscore followed by letters, digits, and underscores.
+// Numbers start with a digit followed by letters, digits, underscores, and periods,
+// so that numbers like 0x1F and 1.5e3 are one token. The whitespace is skipped.
+func Code(text string) ([]string, []int) {
+	return scan(text, func(r rune) (func(r rune) bool, bool) {
+		switch {
+		case unicode.IsSpace(r):
+			return nil, false
+		case unicode.IsDigit(r):
+			return isNumber, true
+		case isWord(r):
+			return isWord, true
+		default:
+			return nil, true
+		}
+	})
+}
+
+// Sentences splits the text into sentences. A sentence ends with a period, exclamation mark,
+// or question mark which is followed by whitespace or the end of the text.
+// The whitespace between sentences is skipped.
+func Sentences(text string) ([]string, []int) {
+	tokens, offsets := []string{}, []int{}
+	start := -1
+	for index := 0; index < len(text); {
+		r, size := utf8.DecodeRuneInString(text[index:])
+		if start < 0 {
+			if !unicode.IsSpace(r) {
+				start = index
+			}
+		} else if r == '.' || r == '!' || r == '?' {
+			if next, _ := utf8.DecodeRuneInString(text[index+size:]); index+size >= len(text) || unicode.IsSpace(next) {
+				tokens = append(tokens, text[start:index+size])
+				offsets = append(offsets, start)
+				start = -1
+			}
+		}
+		index += size
+	}
+	if start >= 0 {
+		tokens = append(tokens, text[start:])
+		offsets = append(offsets, start)
+	}
+	return tokens, offsets
+}
+
+// scan splits the text into tokens using the given start check.
+// The start check is called with the first rune of a token. It returns if the token should be kept
+// and a continue check for the runes after the first, or nil for a token with only one rune.
+func scan(text string, start func(r rune) (func(r rune) bool, bool)) ([]string, []int) {
+	tokens, offsets := []string{}, []int{}
+	for index := 0; index < len(text); {
+		r, size := utf8.DecodeRuneInString(text[index:])
+		end := index + size
+		check, keep := start(r)
+		if check != nil {
+			for end < len(text) {
+				r, size = utf8.DecodeRuneInString(text[end:])
+				if !check(r) {
+					break
+				}
+				end += size
+			}
+		}
+		if keep {
+			tokens = append(tokens, text[index:end])
+			offsets = append(offsets, index)
+		}
+		index = end
+	}
+	return tokens, offsets
+}
+
+// isWord determines if the given rune is part of a word.
+func isWord(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isNumber determines if the given rune is part of a number.
+func isNumber(r rune) bool {
+	return r == '.' || isWord(r)
+}
+
+// isSpace determines if the given rune is whitespace other than a newline.
+func isSpace(r rune) bool {
+	return r != '\n' && unicode.IsSpace(r)
+}
+
+// isNotSpace determines if the given rune is not whitespace.
+func isNotSpace(r rune) bool {
+	return !unicode.IsSpace(r)
+}
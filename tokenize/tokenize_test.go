@@ -0,0 +1,62 @@
+package tokenize
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func Test_Runes(t *testing.T) {
+	checkTokens(t, Runes, ``, ``)
+	checkTokens(t, Runes, `ab c`, `0:a 1:b 2:  3:c`)
+	checkTokens(t, Runes, `aïb`, `0:a 1:ï 3:b`)
+}
+
+func Test_Words(t *testing.T) {
+	checkTokens(t, Words, ``, ``)
+	checkTokens(t, Words, `  `, ``)
+	checkTokens(t, Words, `Hello, world!  x`, `0:Hello, 7:world! 15:x`)
+	checkTokens(t, Words, "one\ttwo\nthree ", "0:one 4:two 8:three")
+}
+
+func Test_WordsAndSpace(t *testing.T) {
+	checkTokens(t, WordsAndSpace, ``, ``)
+	checkTokens(t, WordsAndSpace, `Hello, world!  x`, `0:Hello 5:, 6:  7:world 12:! 13:   15:x`)
+	checkTokens(t, WordsAndSpace, "a \n\n b_2", "0:a 1:  2:\n 3:\n 4:  5:b_2")
+	checkTokens(t, WordsAndSpace, `naïve café`, `0:naïve 6:  7:café`)
+}
+
+func Test_Code(t *testing.T) {
+	checkTokens(t, Code, ``, ``)
+	checkTokens(t, Code, `x := foo_bar(12, 0x1F) + 1.5e3;`,
+		`0:x 2:: 3:= 5:foo_bar 12:( 13:12 15:, 17:0x1F 21:) 23:+ 25:1.5e3 30:;`)
+	checkTokens(t, Code, `a.b2`, `0:a 1:. 2:b2`)
+}
+
+func Test_Sentences(t *testing.T) {
+	checkTokens(t, Sentences, ``, ``)
+	checkTokens(t, Sentences, `One. Two! Three? Four`, `0:One. 5:Two! 10:Three? 17:Four`)
+	checkTokens(t, Sentences, "  Version 1.5 is out.\nGet it. ", "2:Version 1.5 is out. 22:Get it.")
+}
+
+// checkTokens checks the tokens and offsets, written as "offset:token", from the given tokenizer.
+func checkTokens(t *testing.T, tokenizer Tokenizer, text, exp string) {
+	tokens, offsets := tokenizer(text)
+	if len(tokens) != len(offsets) {
+		t.Error("Expected the same number of tokens and offsets: ", len(tokens), " != ", len(offsets))
+		return
+	}
+	parts := make([]string, len(tokens))
+	for i, token := range tokens {
+		if text[offsets[i]:offsets[i]+len(token)] != token {
+			t.Error("Token ", i, " does not match the text at its offset: ", token)
+		}
+		parts[i] = fmt.Sprintf(`%d:%s`, offsets[i], token)
+	}
+	if result := strings.Join(parts, ` `); result != exp {
+		t.Error("Tokenizer returned unexpected result:",
+			"\n   Input:    ", text,
+			"\n   Expected: ", exp,
+			"\n   Result:   ", result)
+	}
+}
@@ -0,0 +1,205 @@
+package godiff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Grant-Nelson/goDiff/comparable"
+	"github.com/Grant-Nelson/goDiff/step"
+)
+
+// noNewlineMarker is the line added after a line which doesn't end with a newline.
+const noNewlineMarker = `\ No newline at end of file`
+
+// Unified gets the unified diff of the two given slices of lines, as used by
+// `diff -u` and `git diff`, which can be applied with `patch` or `git apply`.
+// The given names are used in the `---` and `+++` headers, and the given number
+// of context lines are included around each change. Changes which are close
+// enough together to share context lines are put into the same hunk.
+//
+// The lines are expected to be split from the text with strings.Split(text, "\n"),
+// so when the text ends with a newline the last line is empty. When the text doesn't
+// end with a newline, the "\ No newline at end of file" marker is added after its last line.
+// The result is empty if there are no differences, otherwise each line of the result ends with a newline.
+// This will use the default diff configuration to perform the diff.
+func Unified(aName, bName string, a, b []string, contextLines int) string {
+	return UnifiedCustom(nil, aName, bName, a, b, contextLines)
+}
+
+// UnifiedCustom gets the unified diff of the two given slices of lines, as used by
+// `diff -u` and `git diff`, which can be applied with `patch` or `git apply`.
+// See Unified for more information. This can use any given diff algorithm.
+// If the diff fails the result is empty, use UnifiedCustomErr to get the error.
+func UnifiedCustom(diff Algorithm, aName, bName string, a, b []string, contextLines int) string {
+	result, _ := UnifiedCustomErr(diff, aName, bName, a, b, contextLines)
+	return result
+}
+
+// UnifiedCustomErr gets the unified diff of the two given slices of lines
+// the same as UnifiedCustom but returns the error from Results.Err if the diff failed.
+func UnifiedCustomErr(diff Algorithm, aName, bName string, a, b []string, contextLines int) (string, error) {
+	if diff == nil {
+		diff = DefaultDiff()
+	}
+	if contextLines < 0 {
+		contextLines = 0
+	}
+
+	aLines, bLines := terminatedLines(a), terminatedLines(b)
+	path := diff(comparable.NewString(aLines, bLines))
+	if err := path.Err(); err != nil {
+		return ``, err
+	}
+	edits := unifiedEdits(path)
+
+	buf := &strings.Builder{}
+	for start := 0; start < len(edits); {
+		first := nextChange(edits, start)
+		if first >= len(edits) {
+			break
+		}
+		if buf.Len() <= 0 {
+			fmt.Fprintf(buf, "--- %s\n+++ %s\n", aName, bName)
+		}
+
+		// Extend the hunk while the next change is close enough to share context.
+		last := first
+		for next := nextChange(edits, last+1); next < len(edits) && next-last <= contextLines*2+1; next = nextChange(edits, last+1) {
+			last = next
+		}
+
+		low := maxInt(first-contextLines, 0)
+		high := minInt(last+contextLines+1, len(edits))
+		writeHunk(buf, edits[low:high], aLines, bLines)
+		start = high
+	}
+	return buf.String(), nil
+}
+
+// unifiedEdit is a single line of a diff with the index of the line in A and B.
+// The index is the index of the line the edit is at, even if the line isn't part of that side.
+type unifiedEdit struct {
+	stepType step.Type
+	aIndex   int
+	bIndex   int
+}
+
+// terminatedLines gets the lines with their newline terminators. This allows a last line
+// without a newline to be different from the same line with a newline.
+func terminatedLines(lines []string) []string {
+	count := len(lines)
+	if count > 0 && lines[count-1] == `` {
+		count--
+	}
+
+	result := make([]string, count)
+	for i := 0; i < count; i++ {
+		result[i] = lines[i] + "\n"
+	}
+	if count > 0 && count == len(lines) {
+		result[count-1] = lines[count-1]
+	}
+	return result
+}
+
+// unifiedEdits expands the given path into the edit for each line.
+func unifiedEdits(path Results) []unifiedEdit {
+	edits := make([]unifiedEdit, 0, path.Total())
+	aIndex, bIndex := 0, 0
+	path.Read(func(stepType step.Type, count int) {
+		for i := 0; i < count; i++ {
+			edits = append(edits, unifiedEdit{
+				stepType: stepType,
+				aIndex:   aIndex,
+				bIndex:   bIndex,
+			})
+			switch stepType {
+			case step.Equal:
+				aIndex++
+				bIndex++
+			case step.Added:
+				bIndex++
+			case step.Removed:
+				aIndex++
+			}
+		}
+	})
+	return edits
+}
+
+// nextChange gets the index of the next edit, starting at the given index,
+// which isn't equal. Returns the length of the edits if there are no more changes.
+func nextChange(edits []unifiedEdit, index int) int {
+	for index < len(edits) && edits[index].stepType == step.Equal {
+		index++
+	}
+	return index
+}
+
+// writeHunk writes the header and lines of a hunk for the given edits.
+func writeHunk(buf *strings.Builder, edits []unifiedEdit, aLines, bLines []string) {
+	aCount, bCount := 0, 0
+	for _, edit := range edits {
+		if edit.stepType != step.Added {
+			aCount++
+		}
+		if edit.stepType != step.Removed {
+			bCount++
+		}
+	}
+
+	fmt.Fprintf(buf, "@@ -%s +%s @@\n",
+		hunkRange(edits[0].aIndex, aCount),
+		hunkRange(edits[0].bIndex, bCount))
+
+	for _, edit := range edits {
+		switch edit.stepType {
+		case step.Equal:
+			writeHunkLine(buf, ' ', aLines[edit.aIndex])
+		case step.Added:
+			writeHunkLine(buf, '+', bLines[edit.bIndex])
+		case step.Removed:
+			writeHunkLine(buf, '-', aLines[edit.aIndex])
+		}
+	}
+}
+
+// hunkRange gets the line range for one side of a hunk header.
+// The line numbers start at one. When the count is zero the line is the one
+// before the hunk. The count is left off when it is one.
+func hunkRange(index, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf(`%d,0`, index)
+	case 1:
+		return fmt.Sprintf(`%d`, index+1)
+	default:
+		return fmt.Sprintf(`%d,%d`, index+1, count)
+	}
+}
+
+// writeHunkLine writes a line of a hunk with the given prefix.
+// If the line doesn't end with a newline then the no newline marker is added.
+func writeHunkLine(buf *strings.Builder, prefix byte, line string) {
+	buf.WriteByte(prefix)
+	buf.WriteString(line)
+	if !strings.HasSuffix(line, "\n") {
+		buf.WriteString("\n" + noNewlineMarker + "\n")
+	}
+}
+
+// minInt gets the smaller of the two given values.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// maxInt gets the larger of the two given values.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
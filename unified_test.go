@@ -0,0 +1,131 @@
+package godiff
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_Unified_Hunks(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	b := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\neleven\n12\n"
+	checkUnified(t, a, b, 3,
+		"--- a\n"+
+			"+++ b\n"+
+			"@@ -1,6 +1,6 @@\n"+
+			" 1\n"+
+			" 2\n"+
+			"-3\n"+
+			"+three\n"+
+			" 4\n"+
+			" 5\n"+
+			" 6\n"+
+			"@@ -8,5 +8,5 @@\n"+
+			" 8\n"+
+			" 9\n"+
+			" 10\n"+
+			"-11\n"+
+			"+eleven\n"+
+			" 12\n")
+	checkUnified(t, a, b, 4,
+		"--- a\n"+
+			"+++ b\n"+
+			"@@ -1,12 +1,12 @@\n"+
+			" 1\n"+
+			" 2\n"+
+			"-3\n"+
+			"+three\n"+
+			" 4\n"+
+			" 5\n"+
+			" 6\n"+
+			" 7\n"+
+			" 8\n"+
+			" 9\n"+
+			" 10\n"+
+			"-11\n"+
+			"+eleven\n"+
+			" 12\n")
+	checkUnified(t, a, b, 0,
+		"--- a\n"+
+			"+++ b\n"+
+			"@@ -3 +3 @@\n"+
+			"-3\n"+
+			"+three\n"+
+			"@@ -11 +11 @@\n"+
+			"-11\n"+
+			"+eleven\n")
+}
+
+func Test_Unified_AddRemove(t *testing.T) {
+	checkUnified(t, "1\n2\n3\n", "1\n2\n3\n", 3, "")
+	checkUnified(t, "", "1\n2\n", 3,
+		"--- a\n"+
+			"+++ b\n"+
+			"@@ -0,0 +1,2 @@\n"+
+			"+1\n"+
+			"+2\n")
+	checkUnified(t, "1\n2\n", "", 3,
+		"--- a\n"+
+			"+++ b\n"+
+			"@@ -1,2 +0,0 @@\n"+
+			"-1\n"+
+			"-2\n")
+	checkUnified(t, "1\n2\n3\n4\n", "1\n2\nnew\n3\n4\n", 0,
+		"--- a\n"+
+			"+++ b\n"+
+			"@@ -2,0 +3 @@\n"+
+			"+new\n")
+	checkUnified(t, "1\n2\n3\n4\n", "1\n2\n4\n", 1,
+		"--- a\n"+
+			"+++ b\n"+
+			"@@ -2,3 +2,2 @@\n"+
+			" 2\n"+
+			"-3\n"+
+			" 4\n")
+}
+
+func Test_Unified_NoNewline(t *testing.T) {
+	checkUnified(t, "1\n2", "1\n2\n", 3,
+		"--- a\n"+
+			"+++ b\n"+
+			"@@ -1,2 +1,2 @@\n"+
+			" 1\n"+
+			"-2\n"+
+			"\\ No newline at end of file\n"+
+			"+2\n")
+	checkUnified(t, "1\n2\n", "1\n2", 3,
+		"--- a\n"+
+			"+++ b\n"+
+			"@@ -1,2 +1,2 @@\n"+
+			" 1\n"+
+			"-2\n"+
+			"+2\n"+
+			"\\ No newline at end of file\n")
+	checkUnified(t, "1\n2", "0\n1\n2", 1,
+		"--- a\n"+
+			"+++ b\n"+
+			"@@ -1 +1,2 @@\n"+
+			"+0\n"+
+			" 1\n")
+	checkUnified(t, "1\n2\n3", "1\n4\n3", 1,
+		"--- a\n"+
+			"+++ b\n"+
+			"@@ -1,3 +1,3 @@\n"+
+			" 1\n"+
+			"-2\n"+
+			"+4\n"+
+			" 3\n"+
+			"\\ No newline at end of file\n")
+}
+
+// checkUnified checks the unified diff of the two given texts.
+func checkUnified(t *testing.T, a, b string, contextLines int, exp string) {
+	result := Unified(`a`, `b`, strings.Split(a, "\n"), strings.Split(b, "\n"), contextLines)
+	if exp != result {
+		t.Error("Unified returned unexpected result:",
+			"\n   Input A:  ", a,
+			"\n   Input B:  ", b,
+			"\n   Context:  ", contextLines,
+			"\n   Expected: ", exp,
+			"\n   Result:   ", result)
+	}
+}